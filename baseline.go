@@ -0,0 +1,198 @@
+package markparsr
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+const baselineVersion = 1
+
+type BaselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Rule        string `json:"rule"`
+	Module      string `json:"module"`
+	Item        string `json:"item"`
+}
+
+type baselineFile struct {
+	Version int             `json:"version"`
+	Entries []BaselineEntry `json:"entries"`
+}
+
+// Baseline holds findings accepted when markparsr was adopted. Findings are
+// fingerprinted by rule, module and item rather than by line, so entries keep
+// matching while the README and .tf files are edited. Module paths are stored
+// relative to the baseline file, which is typically kept at the repository
+// root.
+type Baseline struct {
+	path    string
+	dir     string
+	entries map[string]BaselineEntry
+}
+
+// LoadBaseline reads a baseline written by WriteBaseline.
+func LoadBaseline(path string) (*Baseline, error) {
+	baseline, err := newBaseline(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(baseline.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	var file baselineFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	if file.Version != baselineVersion {
+		return nil, fmt.Errorf("unsupported baseline version %d in %s", file.Version, path)
+	}
+
+	for _, entry := range file.Entries {
+		baseline.entries[entry.Fingerprint] = entry
+	}
+	return baseline, nil
+}
+
+// WriteBaseline records the errors and warnings of results in the baseline at
+// path, including those a baseline already suppressed, but not those
+// suppressed by inline comments. Entries of modules not among results are kept, so a
+// baseline can be refreshed one module at a time.
+func WriteBaseline(path string, results ...*Result) error {
+	baseline, err := LoadBaseline(path)
+	if errors.Is(err, os.ErrNotExist) {
+		baseline, err = newBaseline(path)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		module := baseline.module(result.ModulePath)
+		for fingerprint, entry := range baseline.entries {
+			if entry.Module == module {
+				delete(baseline.entries, fingerprint)
+			}
+		}
+		for _, finding := range append(result.Findings(), result.Baselined...) {
+			if !recordable(finding) {
+				continue
+			}
+			entry := baseline.entry(result.ModulePath, finding)
+			baseline.entries[entry.Fingerprint] = entry
+		}
+	}
+
+	return baseline.save()
+}
+
+// recordable leaves out notices, markparsr's own bookkeeping of suppressions
+// and baseline entries, and findings of a run that was cut short, none of
+// which would recur in the same form on the next run.
+func recordable(finding *Finding) bool {
+	switch {
+	case finding.Severity == SeverityInfo:
+	case finding.Rule == RuleBaselineStale || finding.Rule == RuleUnusedSuppression:
+	case isContextError(finding):
+	default:
+		return true
+	}
+	return false
+}
+
+func newBaseline(path string) (*Baseline, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute baseline path: %w", err)
+	}
+	return &Baseline{path: abs, dir: filepath.Dir(abs), entries: make(map[string]BaselineEntry)}, nil
+}
+
+func (b *Baseline) Entries() []BaselineEntry {
+	entries := make([]BaselineEntry, 0, len(b.entries))
+	for _, entry := range b.entries {
+		entries = append(entries, entry)
+	}
+	slices.SortFunc(entries, func(a, b BaselineEntry) int {
+		return cmp.Or(cmp.Compare(a.Module, b.Module), cmp.Compare(a.Rule, b.Rule), cmp.Compare(a.Item, b.Item))
+	})
+	return entries
+}
+
+func (b *Baseline) module(modulePath string) string {
+	if rel, err := filepath.Rel(b.dir, modulePath); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(modulePath)
+}
+
+// entry fingerprints finding within the module. Findings without an item,
+// such as missing sections, fall back to their message, which never contains
+// a line number; messages with run-dependent counts come only from cut-short
+// runs, which are not recorded.
+func (b *Baseline) entry(modulePath string, finding *Finding) BaselineEntry {
+	item := finding.Item
+	if item == "" {
+		item = finding.Message
+	}
+	module := b.module(modulePath)
+
+	sum := sha256.Sum256([]byte(finding.Rule + "|" + module + "|" + item))
+	return BaselineEntry{
+		Fingerprint: hex.EncodeToString(sum[:8]),
+		Rule:        finding.Rule,
+		Module:      module,
+		Item:        item,
+	}
+}
+
+func (b *Baseline) contains(modulePath string, finding *Finding) (string, bool) {
+	entry := b.entry(modulePath, finding)
+	_, ok := b.entries[entry.Fingerprint]
+	return entry.Fingerprint, ok
+}
+
+// stale returns a finding for every entry of the module that no longer
+// matches, so fixed findings are removed from the baseline over time.
+func (b *Baseline) stale(modulePath string, matched map[string]bool) []error {
+	module := b.module(modulePath)
+
+	var findings []error
+	for _, entry := range b.Entries() {
+		if entry.Module != module || matched[entry.Fingerprint] {
+			continue
+		}
+		findings = append(findings, &Finding{
+			Rule:     RuleBaselineStale,
+			Severity: SeverityWarning,
+			Location: Location{File: b.path},
+			Item:     entry.Fingerprint,
+			Message:  fmt.Sprintf("baseline entry no longer matches a finding: %s: %s", entry.Rule, entry.Item),
+		})
+	}
+	return findings
+}
+
+func (b *Baseline) save() error {
+	data, err := json.MarshalIndent(baselineFile{Version: baselineVersion, Entries: b.Entries()}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+
+	if err := os.MkdirAll(b.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create baseline directory: %w", err)
+	}
+	if err := os.WriteFile(b.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package markparsr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBaseline_Fingerprint(t *testing.T) {
+	baseline, _ := newBaseline(filepath.Join("/repo", "markparsr-baseline.json"))
+
+	moved := &Finding{Rule: RuleVariables, Item: "location", Message: "missing", Location: Location{File: "/repo/modules/a/variables.tf", Line: 3}}
+	edited := &Finding{Rule: RuleVariables, Item: "location", Message: "missing", Location: Location{File: "/repo/modules/a/variables.tf", Line: 40}}
+	if baseline.entry("/repo/modules/a", moved) != baseline.entry("/repo/modules/a", edited) {
+		t.Error("fingerprint changed with the line number")
+	}
+
+	entry := baseline.entry("/repo/modules/a", moved)
+	if entry.Module != "modules/a" || entry.Rule != RuleVariables || entry.Item != "location" || len(entry.Fingerprint) != 16 {
+		t.Errorf("entry = %+v", entry)
+	}
+
+	if baseline.entry("/repo/modules/b", moved) == entry {
+		t.Error("fingerprint does not depend on the module")
+	}
+	if got := baseline.entry("/repo/modules/a", &Finding{Rule: RuleSections, Message: "required section missing: 'Outputs'"}); got.Item != "required section missing: 'Outputs'" {
+		t.Errorf("entry without item = %+v; want the message as item", got)
+	}
+}
+
+func TestLoadBaseline_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := LoadBaseline(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("LoadBaseline() expected error for a missing file")
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	os.WriteFile(invalid, []byte("{"), 0o644)
+	if _, err := LoadBaseline(invalid); err == nil {
+		t.Error("LoadBaseline() expected error for invalid JSON")
+	}
+
+	future := filepath.Join(dir, "future.json")
+	os.WriteFile(future, []byte(`{"version": 2, "entries": []}`), 0o644)
+	if _, err := LoadBaseline(future); err == nil || !strings.Contains(err.Error(), "unsupported baseline version") {
+		t.Errorf("LoadBaseline() error = %v; want unsupported version", err)
+	}
+}
+
+func TestWriteBaseline_KeepsOtherModules(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "baseline.json")
+
+	result := func(module, item string) *Result {
+		return &Result{
+			ModulePath: filepath.Join(dir, module),
+			Validators: []ValidatorRun{{Findings: []*Finding{{Rule: RuleOutputs, Item: item}}}},
+		}
+	}
+
+	if err := WriteBaseline(path, result("a", "id"), result("b", "name")); err != nil {
+		t.Fatalf("WriteBaseline() error = %v", err)
+	}
+	if err := WriteBaseline(path, result("a", "arn")); err != nil {
+		t.Fatalf("WriteBaseline() error = %v", err)
+	}
+
+	baseline, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatalf("LoadBaseline() error = %v", err)
+	}
+
+	var got []string
+	for _, entry := range baseline.Entries() {
+		got = append(got, entry.Module+":"+entry.Item)
+	}
+	if strings.Join(got, ",") != "a:arn,b:name" {
+		t.Errorf("entries = %v; want a:arn,b:name", got)
+	}
+}
+
+func TestWriteBaseline_SkipsTransientFindings(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "baseline.json")
+
+	stopped := asFinding(fmt.Errorf("URL validation stopped with 2 of 3 URLs unchecked: %w", context.Canceled), RuleURL)
+	result := &Result{
+		ModulePath: dir,
+		Validators: []ValidatorRun{{Findings: []*Finding{
+			{Rule: RuleOutputs, Severity: SeverityError, Item: "id"},
+			{Rule: RuleURL, Severity: SeverityWarning, Item: "https://example.com/moved"},
+			{Rule: RuleURL, Severity: SeverityInfo, Item: "https://example.com/new"},
+			{Rule: RuleUnusedSuppression, Severity: SeverityWarning, Message: "suppression does not match any finding: url"},
+			stopped,
+		}}},
+	}
+	if err := WriteBaseline(path, result); err != nil {
+		t.Fatalf("WriteBaseline() error = %v", err)
+	}
+
+	baseline, _ := LoadBaseline(path)
+	var got []string
+	for _, entry := range baseline.Entries() {
+		got = append(got, entry.Rule+":"+entry.Item)
+	}
+	if strings.Join(got, ",") != "outputs:id,url:https://example.com/moved" {
+		t.Errorf("entries = %v; want only the error and warning", got)
+	}
+}
+
+func TestReadmeValidator_Baseline(t *testing.T) {
+	root := t.TempDir()
+	moduleDir := filepath.Join(root, "modules", "network")
+	os.MkdirAll(moduleDir, 0o755)
+	readmePath := filepath.Join(moduleDir, "README.md")
+	baselinePath := filepath.Join(root, "markparsr-baseline.json")
+
+	os.WriteFile(readmePath, []byte("# Network\n\n## Outputs\n\nNone\n"), 0o644)
+	os.WriteFile(filepath.Join(moduleDir, "outputs.tf"), []byte("output \"id\" {\n  value = \"x\"\n}\n"), 0o644)
+
+	rv, err := NewReadmeValidator(WithRelativeReadmePath(readmePath))
+	if err != nil {
+		t.Fatalf("NewReadmeValidator() error = %v", err)
+	}
+	before := rv.Run(t.Context())
+	if before.Passed() {
+		t.Fatal("Run() passed before the baseline; want findings to record")
+	}
+	if err := WriteBaseline(baselinePath, before); err != nil {
+		t.Fatalf("WriteBaseline() error = %v", err)
+	}
+
+	rv, err = NewReadmeValidator(WithRelativeReadmePath(readmePath), WithBaselineFile(baselinePath))
+	if err != nil {
+		t.Fatalf("NewReadmeValidator() error = %v", err)
+	}
+	after := rv.Run(t.Context())
+	if !after.Passed() || len(after.Warnings) != 0 || len(after.Baselined) != len(before.Findings()) {
+		t.Fatalf("Run() with baseline = errors %v, warnings %v, %d baselined; want all %d findings baselined",
+			after.Errors, after.Warnings, len(after.Baselined), len(before.Findings()))
+	}
+
+	os.WriteFile(readmePath, []byte("# Network\n\n## Outputs\n\n### <a name=\"output_id\"></a> id\n\nThe id.\n"), 0o644)
+	rv, _ = NewReadmeValidator(WithRelativeReadmePath(readmePath), WithBaselineFile(baselinePath))
+	fixed := rv.Run(t.Context())
+
+	var stale []*Finding
+	for _, finding := range fixed.Findings() {
+		if finding.Rule == RuleBaselineStale {
+			stale = append(stale, finding)
+		}
+	}
+	if len(stale) != 1 || !strings.Contains(stale[0].Message, "outputs: id") || stale[0].Severity != SeverityWarning || stale[0].Location.File != baselinePath {
+		t.Errorf("stale findings = %v; want the fixed output", stale)
+	}
+	if !fixed.Passed() {
+		t.Errorf("Run() errors = %v; want stale entries as warnings only", fixed.Errors)
+	}
+}
+
+func TestWriteBaseline_RefreshIsStable(t *testing.T) {
+	root := t.TempDir()
+	readmePath := filepath.Join(root, "README.md")
+	baselinePath := filepath.Join(root, "markparsr-baseline.json")
+
+	os.WriteFile(readmePath, []byte("# Network\n\n## Outputs\n\nNone\n"), 0o644)
+	os.WriteFile(filepath.Join(root, "outputs.tf"), []byte("output \"id\" {\n  value = \"x\"\n}\n"), 0o644)
+
+	rv, err := NewReadmeValidator(WithRelativeReadmePath(readmePath))
+	if err != nil {
+		t.Fatalf("NewReadmeValidator() error = %v", err)
+	}
+	if err := WriteBaseline(baselinePath, rv.Run(t.Context())); err != nil {
+		t.Fatalf("WriteBaseline() error = %v", err)
+	}
+	written, _ := os.ReadFile(baselinePath)
+
+	rv, err = NewReadmeValidator(WithRelativeReadmePath(readmePath), WithBaselineFile(baselinePath))
+	if err != nil {
+		t.Fatalf("NewReadmeValidator() error = %v", err)
+	}
+	result := rv.Run(t.Context())
+	if len(result.Baselined) == 0 {
+		t.Fatal("Run() with baseline baselined nothing; want the recorded findings")
+	}
+	if err := WriteBaseline(baselinePath, result); err != nil {
+		t.Fatalf("WriteBaseline() error = %v", err)
+	}
+	rewritten, _ := os.ReadFile(baselinePath)
+
+	if string(rewritten) != string(written) {
+		t.Errorf("rewritten baseline =\n%s\nwant\n%s", rewritten, written)
+	}
+}
@@ -0,0 +1,164 @@
+package markparsr
+
+import (
+	"fmt"
+	"strings"
+)
+
+type CoverageMetric struct {
+	Documented int
+	Total      int
+}
+
+// Percent returns the documented share in percent; a metric with nothing to
+// document is fully covered.
+func (m CoverageMetric) Percent() float64 {
+	if m.Total == 0 {
+		return 100
+	}
+	return 100 * float64(m.Documented) / float64(m.Total)
+}
+
+func (m CoverageMetric) add(other CoverageMetric) CoverageMetric {
+	return CoverageMetric{Documented: m.Documented + other.Documented, Total: m.Total + other.Total}
+}
+
+// Coverage measures how much of a module the README documents, and how many
+// variables and outputs carry a description in HCL.
+type Coverage struct {
+	Variables            CoverageMetric
+	Outputs              CoverageMetric
+	Resources            CoverageMetric
+	DataSources          CoverageMetric
+	VariableDescriptions CoverageMetric
+	OutputDescriptions   CoverageMetric
+}
+
+// Documentation combines the variable, output, resource and data source
+// metrics into one.
+func (c *Coverage) Documentation() CoverageMetric {
+	return c.Variables.add(c.Outputs).add(c.Resources).add(c.DataSources)
+}
+
+type namedCoverageMetric struct {
+	name   string
+	metric CoverageMetric
+}
+
+func (c *Coverage) metrics() []namedCoverageMetric {
+	return []namedCoverageMetric{
+		{"variables", c.Variables},
+		{"outputs", c.Outputs},
+		{"resources", c.Resources},
+		{"data sources", c.DataSources},
+		{"variable descriptions", c.VariableDescriptions},
+		{"output descriptions", c.OutputDescriptions},
+	}
+}
+
+func computeCoverage(markdown *MarkdownContent, terraform *TerraformContent, ignore ItemIgnores) (*Coverage, error) {
+	module, err := terraform.Module()
+	if err != nil {
+		return nil, err
+	}
+
+	mdResources, mdDataSources, _ := markdown.ExtractResourcesAndDataSources()
+
+	suppressions := terraformSuppressions(terraform)
+	variables := withoutSuppressed(ignore.filterBlocks(module.Variables), suppressions, RuleVariables)
+	outputs := withoutSuppressed(ignore.filterBlocks(module.Outputs), suppressions, RuleOutputs)
+	resources := withoutSuppressed(ignore.filterBlocks(module.Resources), suppressions, RuleResources)
+	dataSources := withoutSuppressed(ignore.filterBlocks(module.DataSources), suppressions, RuleResources)
+
+	return &Coverage{
+		Variables:            documentedItems(blockNames(variables), markdown.ExtractSectionItems("Required Inputs", "Optional Inputs")),
+		Outputs:              documentedItems(blockNames(outputs), markdown.ExtractSectionItems("Outputs")),
+		Resources:            documentedItems(resourceItems(resources), mdResources),
+		DataSources:          documentedItems(resourceItems(dataSources), mdDataSources),
+		VariableDescriptions: describedBlocks(variables),
+		OutputDescriptions:   describedBlocks(outputs),
+	}, nil
+}
+
+// documentedItems counts the Terraform items that the README lists, matching
+// them the same way the item validators do.
+func documentedItems(tfItems, mdItems []string) CoverageMetric {
+	mdIndex := buildItemIndex(mdItems)
+
+	var metric CoverageMetric
+	for _, entry := range buildItemIndex(tfItems).items() {
+		metric.Total++
+		if mdIndex.hasMatch(entry) {
+			metric.Documented++
+		}
+	}
+	return metric
+}
+
+func describedBlocks(blocks []*TerraformBlock) CoverageMetric {
+	metric := CoverageMetric{Total: len(blocks)}
+	for _, block := range blocks {
+		if description, ok := block.StringAttribute("description"); ok && strings.TrimSpace(description) != "" {
+			metric.Documented++
+		}
+	}
+	return metric
+}
+
+// resourceItems lists each resource or data source by its type and by its
+// full name, as ExtractResourcesAndDataSources does.
+func resourceItems(blocks []*TerraformBlock) []string {
+	items := make([]string, 0, 2*len(blocks))
+	for _, block := range blocks {
+		items = append(items, block.Labels[0], block.Name())
+	}
+	return items
+}
+
+func blockNames(blocks []*TerraformBlock) []string {
+	names := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		names = append(names, block.Name())
+	}
+	return names
+}
+
+// CoverageValidator reports every coverage metric that falls below a minimum
+// percentage. Metrics with nothing to document are ignored.
+type CoverageValidator struct {
+	markdown  *MarkdownContent
+	terraform *TerraformContent
+	minimum   float64
+	ignore    ItemIgnores
+}
+
+func NewCoverageValidator(markdown *MarkdownContent, terraform *TerraformContent, minimum float64, ignore ItemIgnores) *CoverageValidator {
+	return &CoverageValidator{
+		markdown:  markdown,
+		terraform: terraform,
+		minimum:   minimum,
+		ignore:    ignore,
+	}
+}
+
+func (cv *CoverageValidator) Validate() []error {
+	coverage, err := computeCoverage(cv.markdown, cv.terraform, cv.ignore)
+	if err != nil {
+		return []error{err}
+	}
+
+	var errors []error
+	for _, m := range coverage.metrics() {
+		if m.metric.Total == 0 || m.metric.Percent() >= cv.minimum {
+			continue
+		}
+		errors = append(errors, &Finding{
+			Rule:     RuleCoverage,
+			Severity: SeverityError,
+			Item:     m.name,
+			Message: fmt.Sprintf("%s coverage %.1f%% (%d of %d) is below the minimum of %.1f%%",
+				m.name, m.metric.Percent(), m.metric.Documented, m.metric.Total, cv.minimum),
+		})
+	}
+	return errors
+}
@@ -0,0 +1,171 @@
+package markparsr
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const coverageMarkdown = `## Resources
+
+- [azurerm_resource_group.main](https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/resources/resource_group) (resource)
+
+## Required Inputs
+
+### <a name="input_name"></a> name
+
+Description
+
+## Outputs
+
+### <a name="output_id"></a> id
+
+Description
+`
+
+const coverageTerraform = `
+variable "name" {
+  description = "The name."
+  type        = string
+}
+
+variable "location" {
+  description = "  "
+  type        = string
+}
+
+output "id" {
+  description = "The id."
+  value       = azurerm_resource_group.main.id
+}
+
+resource "azurerm_resource_group" "main" {
+  name     = var.name
+  location = var.location
+}
+
+resource "azurerm_storage_account" "main" {
+  name = var.name
+}
+
+data "azurerm_client_config" "current" {}
+`
+
+func newCoverageFixture(t *testing.T) (*MarkdownContent, *TerraformContent) {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "main.tf"), []byte(coverageTerraform), 0o644); err != nil {
+		t.Fatalf("Failed to write terraform file: %v", err)
+	}
+	tc, err := NewTerraformContent(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create TerraformContent: %v", err)
+	}
+	return NewMarkdownContent(coverageMarkdown, FormatDocument, []string{"azurerm_"}), tc
+}
+
+func TestComputeCoverage(t *testing.T) {
+	markdown, terraform := newCoverageFixture(t)
+	coverage, err := computeCoverage(markdown, terraform, nil)
+	if err != nil {
+		t.Fatalf("computeCoverage() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		metric CoverageMetric
+		want   CoverageMetric
+	}{
+		{"variables", coverage.Variables, CoverageMetric{Documented: 1, Total: 2}},
+		{"outputs", coverage.Outputs, CoverageMetric{Documented: 1, Total: 1}},
+		{"resources", coverage.Resources, CoverageMetric{Documented: 1, Total: 2}},
+		{"data sources", coverage.DataSources, CoverageMetric{Documented: 0, Total: 1}},
+		{"variable descriptions", coverage.VariableDescriptions, CoverageMetric{Documented: 1, Total: 2}},
+		{"output descriptions", coverage.OutputDescriptions, CoverageMetric{Documented: 1, Total: 1}},
+		{"documentation", coverage.Documentation(), CoverageMetric{Documented: 3, Total: 6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.metric != tt.want {
+				t.Errorf("%s coverage = %+v; want %+v", tt.name, tt.metric, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeCoverage_IgnoreDirectives(t *testing.T) {
+	tmpDir := t.TempDir()
+	terraform := strings.Replace(coverageTerraform, `variable "location" {`, "# markparsr:ignore variables\nvariable \"location\" {", 1)
+	terraform = strings.Replace(terraform, `resource "azurerm_storage_account" "main" {`, "# markparsr:ignore outputs\nresource \"azurerm_storage_account\" \"main\" {", 1)
+	os.WriteFile(filepath.Join(tmpDir, "main.tf"), []byte(terraform), 0o644)
+	tc, _ := NewTerraformContent(tmpDir)
+	markdown := NewMarkdownContent(coverageMarkdown, FormatDocument, []string{"azurerm_"})
+
+	coverage, err := computeCoverage(markdown, tc, nil)
+	if err != nil {
+		t.Fatalf("computeCoverage() error = %v", err)
+	}
+	if coverage.Variables != (CoverageMetric{Documented: 1, Total: 1}) || coverage.VariableDescriptions != (CoverageMetric{Documented: 1, Total: 1}) {
+		t.Errorf("variable coverage = %+v, %+v; want the ignored variable left out", coverage.Variables, coverage.VariableDescriptions)
+	}
+	if coverage.Resources != (CoverageMetric{Documented: 1, Total: 2}) {
+		t.Errorf("resource coverage = %+v; want a directive for another rule to keep the resource", coverage.Resources)
+	}
+}
+
+func TestCoverageMetric_Percent(t *testing.T) {
+	tests := []struct {
+		metric CoverageMetric
+		want   float64
+	}{
+		{CoverageMetric{Documented: 1, Total: 4}, 25},
+		{CoverageMetric{Documented: 3, Total: 3}, 100},
+		{CoverageMetric{}, 100},
+	}
+
+	for _, tt := range tests {
+		if got := tt.metric.Percent(); got != tt.want {
+			t.Errorf("%+v.Percent() = %v; want %v", tt.metric, got, tt.want)
+		}
+	}
+}
+
+func TestCoverageValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		minimum float64
+		want    []string
+	}{
+		{name: "met", minimum: 0},
+		{name: "half", minimum: 50, want: []string{"data sources"}},
+		{name: "full", minimum: 100, want: []string{"variables", "resources", "data sources", "variable descriptions"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markdown, terraform := newCoverageFixture(t)
+			errs := NewCoverageValidator(markdown, terraform, tt.minimum, nil).Validate()
+
+			var items []string
+			for _, err := range errs {
+				finding := err.(*Finding)
+				if finding.Rule != RuleCoverage {
+					t.Errorf("finding rule = %q; want %q", finding.Rule, RuleCoverage)
+				}
+				items = append(items, finding.Item)
+			}
+			if strings.Join(items, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Validate() items = %v; want %v", items, tt.want)
+			}
+		})
+	}
+
+	markdown, terraform := newCoverageFixture(t)
+	errs := NewCoverageValidator(markdown, terraform, 60, nil).Validate()
+	if len(errs) == 0 || errs[0].Error() != "variables coverage 50.0% (1 of 2) is below the minimum of 60.0%" {
+		t.Errorf("Validate() = %v", errs)
+	}
+}
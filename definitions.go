@@ -3,6 +3,7 @@ package markparsr
 type TerraformDefinitionValidator struct {
 	markdown  *MarkdownContent
 	terraform *TerraformContent
+	ignore    ItemIgnores
 }
 
 func NewTerraformDefinitionValidator(markdown *MarkdownContent, terraform *TerraformContent) *TerraformDefinitionValidator {
@@ -20,6 +21,11 @@ func (tdv *TerraformDefinitionValidator) Validate() []error {
 
 	readmeResources, readmeDataSources, mdErr := tdv.markdown.ExtractResourcesAndDataSources()
 
+	tfResources = tdv.ignore.filter("resource", tfResources)
+	tfDataSources = tdv.ignore.filter("data", tfDataSources)
+	readmeResources = tdv.ignore.filter("resource", readmeResources)
+	readmeDataSources = tdv.ignore.filter("data", readmeDataSources)
+
 	collector := &ErrorCollector{}
 	if len(tfResources)+len(tfDataSources) > 0 {
 		if mdErr != nil {
@@ -29,8 +35,16 @@ func (tdv *TerraformDefinitionValidator) Validate() []error {
 	}
 
 	if tdv.markdown.HasSection("Resources") || len(readmeResources) > 0 || len(readmeDataSources) > 0 {
-		collector.AddMany(compareTerraformAndMarkdown(tfResources, readmeResources, "Resources"))
-		collector.AddMany(compareTerraformAndMarkdown(tfDataSources, readmeDataSources, "Data Sources"))
+		resourceErrs := compareTerraformAndMarkdown(tfResources, readmeResources, "Resources")
+		dataSourceErrs := compareTerraformAndMarkdown(tfDataSources, readmeDataSources, "Data Sources")
+
+		if module, err := tdv.terraform.Module(); err == nil {
+			locateItemFindings(resourceErrs, module.Resources, tdv.markdown, "[%s]")
+			locateItemFindings(dataSourceErrs, module.DataSources, tdv.markdown, "[%s]")
+		}
+
+		collector.AddMany(resourceErrs)
+		collector.AddMany(dataSourceErrs)
 	}
 	return collector.Errors()
 }
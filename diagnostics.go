@@ -0,0 +1,23 @@
+package markparsr
+
+type TerraformDiagnosticsValidator struct {
+	terraform *TerraformContent
+}
+
+func NewTerraformDiagnosticsValidator(terraform *TerraformContent) *TerraformDiagnosticsValidator {
+	return &TerraformDiagnosticsValidator{terraform: terraform}
+}
+
+func (tdv *TerraformDiagnosticsValidator) Validate() []error {
+	diags, err := tdv.terraform.Diagnostics()
+	if err != nil {
+		return []error{err}
+	}
+
+	var errors []error
+	for _, diag := range diags {
+		errors = append(errors, newDiagnosticFinding(RuleHCLSyntax, diag))
+	}
+
+	return errors
+}
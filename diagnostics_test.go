@@ -0,0 +1,118 @@
+package markparsr
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTerraformDiagnosticsValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		expectedErrs  int
+		errorContains []string
+	}{
+		{
+			name: "valid module",
+			files: map[string]string{
+				"variables.tf": `variable "name" {}`,
+			},
+			expectedErrs: 0,
+		},
+		{
+			name: "syntax error in one file",
+			files: map[string]string{
+				"variables.tf": `variable "name" {}`,
+				"outputs.tf":   "output \"id\" {\n  value = \n",
+			},
+			expectedErrs:  1,
+			errorContains: []string{"outputs.tf:"},
+		},
+		{
+			name: "errors in multiple files",
+			files: map[string]string{
+				"variables.tf": `variable "name" {`,
+				"main.tf":      `resource "azurerm_resource_group" {}`,
+			},
+			expectedErrs:  2,
+			errorContains: []string{"variables.tf:", "main.tf:"},
+		},
+		{
+			name: "override without base declaration",
+			files: map[string]string{
+				"variables.tf": `variable "name" {}`,
+				"override.tf":  `variable "other" {}`,
+			},
+			expectedErrs:  1,
+			errorContains: []string{"Missing base declaration to override"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+					t.Fatalf("Failed to write %s: %v", name, err)
+				}
+			}
+
+			tc, _ := NewTolerantTerraformContent(dir)
+
+			errs := NewTerraformDiagnosticsValidator(tc).Validate()
+			if len(errs) != tt.expectedErrs {
+				t.Errorf("Validate() returned %d errors; want %d", len(errs), tt.expectedErrs)
+				for i, err := range errs {
+					t.Logf("  error %d: %v", i+1, err)
+				}
+			}
+
+			for _, substr := range tt.errorContains {
+				found := false
+				for _, err := range errs {
+					if strings.Contains(err.Error(), substr) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Errorf("Expected error containing %q, but not found", substr)
+				}
+			}
+
+			for _, err := range errs {
+				finding, ok := err.(*Finding)
+				if !ok {
+					t.Errorf("Validate() returned %T; want *Finding", err)
+					continue
+				}
+				if finding.Location.File == "" || finding.Location.Line == 0 {
+					t.Errorf("finding %v has no location", finding)
+				}
+			}
+		})
+	}
+}
+
+func TestTerraformContent_TolerantParsing(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "variables.tf"), []byte("variable \"name\" {}\n\nvariable \"location\" {}\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "outputs.tf"), []byte("output \"id\" {\n  value = \n"), 0o644)
+
+	tc, _ := NewTerraformContent(dir)
+
+	if _, err := tc.ExtractModuleItems("variable"); err == nil {
+		t.Error("ExtractModuleItems() in strict mode expected error for invalid file")
+	}
+
+	tc, _ = NewTolerantTerraformContent(dir)
+	items, err := tc.ExtractModuleItems("variable")
+	if err != nil {
+		t.Fatalf("ExtractModuleItems() in tolerant mode error = %v", err)
+	}
+	if len(items) != 2 {
+		t.Errorf("ExtractModuleItems() in tolerant mode returned %v; want [name location]", items)
+	}
+}
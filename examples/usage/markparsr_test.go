@@ -17,7 +17,7 @@ func TestReadmeValidation(t *testing.T) {
 		t.Fatalf("Failed to create validator: %v", err)
 	}
 
-	errors := validator.Validate()
+	errors := validator.ValidateContext(t.Context())
 	if len(errors) > 0 {
 		for _, err := range errors {
 			t.Errorf("Validation error: %v", err)
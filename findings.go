@@ -0,0 +1,97 @@
+package markparsr
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+type Location struct {
+	File      string
+	Line      int
+	Column    int
+	EndLine   int
+	EndColumn int
+}
+
+type Finding struct {
+	Rule       string
+	Severity   Severity
+	Location   Location
+	Item       string
+	Message    string
+	Suggestion string
+	Err        error
+}
+
+func (f *Finding) Error() string {
+	if f.Location.File == "" {
+		return f.Message
+	}
+
+	file := filepath.Base(f.Location.File)
+	if f.Location.Line == 0 {
+		return fmt.Sprintf("%s: %s", file, f.Message)
+	}
+	if f.Location.Column == 0 {
+		return fmt.Sprintf("%s:%d: %s", file, f.Location.Line, f.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", file, f.Location.Line, f.Location.Column, f.Message)
+}
+
+func (f *Finding) Unwrap() error {
+	return f.Err
+}
+
+func severityOf(err error) Severity {
+	var finding *Finding
+	if errors.As(err, &finding) && finding.Severity != "" {
+		return finding.Severity
+	}
+	return SeverityError
+}
+
+func newDiagnosticFinding(rule string, diag *hcl.Diagnostic) *Finding {
+	severity := SeverityError
+	if diag.Severity == hcl.DiagWarning {
+		severity = SeverityWarning
+	}
+
+	message := diag.Summary
+	if detail := strings.TrimSpace(diag.Detail); detail != "" {
+		message += "; " + detail
+	}
+
+	finding := &Finding{
+		Rule:     rule,
+		Severity: severity,
+		Message:  message,
+	}
+
+	if diag.Subject != nil {
+		finding.Location = rangeLocation(*diag.Subject)
+	}
+
+	return finding
+}
+
+func rangeLocation(r hcl.Range) Location {
+	return Location{
+		File:      r.Filename,
+		Line:      r.Start.Line,
+		Column:    r.Start.Column,
+		EndLine:   r.End.Line,
+		EndColumn: r.End.Column,
+	}
+}
@@ -0,0 +1,69 @@
+package markparsr
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestFinding_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		finding  Finding
+		expected string
+	}{
+		{
+			name:     "without location",
+			finding:  Finding{Message: "something is wrong"},
+			expected: "something is wrong",
+		},
+		{
+			name:     "with file only",
+			finding:  Finding{Message: "file is empty", Location: Location{File: "/tmp/module/outputs.tf"}},
+			expected: "outputs.tf: file is empty",
+		},
+		{
+			name:     "with line only",
+			finding:  Finding{Message: "broken link", Location: Location{File: "/tmp/module/README.md", Line: 12}},
+			expected: "README.md:12: broken link",
+		},
+		{
+			name:     "with line and column",
+			finding:  Finding{Message: "Invalid expression", Location: Location{File: "/tmp/module/main.tf", Line: 3, Column: 11}},
+			expected: "main.tf:3:11: Invalid expression",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.finding.Error(); got != tt.expected {
+				t.Errorf("Error() = %q; want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewDiagnosticFinding(t *testing.T) {
+	diag := &hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  "Deprecated syntax",
+		Detail:   "Use the new form.",
+		Subject: &hcl.Range{
+			Filename: "main.tf",
+			Start:    hcl.Pos{Line: 2, Column: 3},
+			End:      hcl.Pos{Line: 2, Column: 9},
+		},
+	}
+
+	finding := newDiagnosticFinding("hcl-syntax", diag)
+
+	if finding.Severity != SeverityWarning {
+		t.Errorf("Severity = %q; want %q", finding.Severity, SeverityWarning)
+	}
+	if finding.Message != "Deprecated syntax; Use the new form." {
+		t.Errorf("Message = %q", finding.Message)
+	}
+	if finding.Location.Line != 2 || finding.Location.EndColumn != 9 {
+		t.Errorf("Location = %+v", finding.Location)
+	}
+}
@@ -0,0 +1,152 @@
+package markparsr
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+type FixtureMode int
+
+const (
+	// FixtureReplay answers only from recorded fixtures and fails requests
+	// that have none, so link checks never touch the network.
+	FixtureReplay FixtureMode = iota
+	// FixtureRecord sends every request and overwrites its fixture.
+	FixtureRecord
+	// FixtureReplayOrRecord replays existing fixtures and records missing ones.
+	FixtureReplayOrRecord
+)
+
+type fixture struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// FixtureTransport is an http.RoundTripper that records responses to a
+// directory, one JSON file per method and URL, and replays them later.
+// Redirects are stored hop by hop because the client follows them itself.
+type FixtureTransport struct {
+	dir  string
+	mode FixtureMode
+	next http.RoundTripper
+}
+
+func NewFixtureTransport(dir string, mode FixtureMode, next http.RoundTripper) *FixtureTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &FixtureTransport{dir: dir, mode: mode, next: next}
+}
+
+func (ft *FixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := ft.fixturePath(req)
+
+	if ft.mode != FixtureRecord {
+		recorded, err := readFixture(path)
+		if err == nil {
+			return recorded.response(req), nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		if ft.mode == FixtureReplay {
+			return nil, fmt.Errorf("no fixture recorded for %s %s", req.Method, req.URL)
+		}
+	}
+
+	resp, err := ft.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response for %s: %w", req.URL, err)
+	}
+
+	recorded := &fixture{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Status: resp.StatusCode,
+		Header: resp.Header,
+		Body:   string(body),
+	}
+	if err := writeFixture(path, recorded); err != nil {
+		return nil, err
+	}
+
+	return recorded.response(req), nil
+}
+
+func (ft *FixtureTransport) fixturePath(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Method + " " + req.URL.String()))
+	return filepath.Join(ft.dir, hex.EncodeToString(sum[:8])+".json")
+}
+
+func (f *fixture) response(req *http.Request) *http.Response {
+	header := f.Header
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.Status, http.StatusText(f.Status)),
+		StatusCode:    f.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader([]byte(f.Body))),
+		ContentLength: int64(len(f.Body)),
+		Request:       req,
+	}
+}
+
+func readFixture(path string) (*fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var recorded fixture
+	if err := json.Unmarshal(data, &recorded); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+	return &recorded, nil
+}
+
+func writeFixture(path string, recorded *fixture) error {
+	data, err := json.MarshalIndent(recorded, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,112 @@
+package markparsr
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFixtureTransport_RecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	live := newSequenceTransport(map[string][]stubResponse{
+		"http://example.com/old": {{status: http.StatusMovedPermanently, header: http.Header{"Location": []string{"/new"}}}},
+		"http://example.com/new": {{status: http.StatusOK}},
+	})
+
+	recorder := &http.Client{Transport: NewFixtureTransport(dir, FixtureRecord, live)}
+	resp, err := recorder.Get("http://example.com/old")
+	if err != nil {
+		t.Fatalf("recording Get() error = %v", err)
+	}
+	resp.Body.Close()
+
+	files, _ := os.ReadDir(dir)
+	if len(files) != 2 {
+		t.Errorf("recorded %d fixtures; want one per redirect hop", len(files))
+	}
+
+	replayer := &http.Client{Transport: NewFixtureTransport(dir, FixtureReplay, live)}
+	resp, err = replayer.Get("http://example.com/old")
+	if err != nil {
+		t.Fatalf("replaying Get() error = %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || resp.Request.URL.String() != "http://example.com/new" {
+		t.Errorf("replayed %d at %s; want 200 at http://example.com/new", resp.StatusCode, resp.Request.URL)
+	}
+	if len(live.requests) != 2 {
+		t.Errorf("replay reached the network: %v", live.requests)
+	}
+}
+
+func TestFixtureTransport_Modes(t *testing.T) {
+	dir := t.TempDir()
+	live := newSequenceTransport(map[string][]stubResponse{
+		"http://example.com/page": {{status: http.StatusNotFound}, {status: http.StatusOK}},
+	})
+
+	if _, err := NewFixtureTransport(dir, FixtureReplay, live).RoundTrip(newTestRequest(t, "http://example.com/page")); err == nil || !strings.Contains(err.Error(), "no fixture recorded") {
+		t.Errorf("replay without fixture error = %v; want missing fixture error", err)
+	}
+
+	resp, err := NewFixtureTransport(dir, FixtureReplayOrRecord, live).RoundTrip(newTestRequest(t, "http://example.com/page"))
+	if err != nil || resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("replay-or-record = %v, %v; want recorded 404", resp, err)
+	}
+
+	resp, _ = NewFixtureTransport(dir, FixtureReplayOrRecord, live).RoundTrip(newTestRequest(t, "http://example.com/page"))
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("second replay-or-record status = %d; want replayed 404", resp.StatusCode)
+	}
+
+	resp, _ = NewFixtureTransport(dir, FixtureRecord, live).RoundTrip(newTestRequest(t, "http://example.com/page"))
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("record status = %d; want fresh 200", resp.StatusCode)
+	}
+
+	resp, _ = NewFixtureTransport(dir, FixtureReplay, nil).RoundTrip(newTestRequest(t, "http://example.com/page"))
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || len(body) != 0 {
+		t.Errorf("replay status = %d, body %q; want overwritten 200", resp.StatusCode, body)
+	}
+}
+
+func TestReadmeValidator_WithHTTPTransport(t *testing.T) {
+	tmpDir := t.TempDir()
+	readmePath := filepath.Join(tmpDir, "README.md")
+	os.WriteFile(readmePath, []byte("See https://example.com/docs and https://example.com/gone for details."), 0o644)
+
+	fixtures := filepath.Join(tmpDir, "fixtures")
+	transport := NewFixtureTransport(fixtures, FixtureReplay, nil)
+	writeFixture(transport.fixturePath(newTestRequest(t, "https://example.com/docs")), &fixture{Status: http.StatusOK})
+
+	rv, err := NewReadmeValidator(
+		WithRelativeReadmePath(readmePath),
+		WithHTTPTransport(transport),
+	)
+	if err != nil {
+		t.Fatalf("NewReadmeValidator() error = %v", err)
+	}
+
+	var urlErrors []string
+	for _, err := range rv.Validate() {
+		if strings.Contains(err.Error(), "example.com") {
+			urlErrors = append(urlErrors, err.Error())
+		}
+	}
+	if len(urlErrors) != 1 || !strings.Contains(urlErrors[0], "no fixture recorded for HEAD https://example.com/gone") {
+		t.Errorf("URL errors = %v; want only the unrecorded URL to fail", urlErrors)
+	}
+}
+
+func newTestRequest(t *testing.T, url string) *http.Request {
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodHead, url, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	return req
+}
@@ -0,0 +1,37 @@
+package markparsr
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlCommentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+	htmlTagPattern     = regexp.MustCompile(`<[a-zA-Z][^>]*>`)
+	anchorAttrPattern  = regexp.MustCompile(`(?i)\s(?:id|name)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+)
+
+// extractAnchors returns the id and name attribute values of every tag in an
+// HTML document. GitHub prefixes rendered heading ids with "user-content-"
+// and resolves the bare fragment in the browser, so both forms are kept.
+func extractAnchors(body []byte) []string {
+	doc := htmlCommentPattern.ReplaceAll(body, nil)
+
+	anchors := &uniqueList{}
+	for _, tag := range htmlTagPattern.FindAll(doc, -1) {
+		for _, match := range anchorAttrPattern.FindAllSubmatch(tag, -1) {
+			value := string(match[1]) + string(match[2]) + string(match[3])
+			value = html.UnescapeString(value)
+			if value == "" {
+				continue
+			}
+			anchors.add(value)
+			if trimmed, ok := strings.CutPrefix(value, "user-content-"); ok {
+				anchors.add(trimmed)
+			}
+		}
+	}
+
+	return anchors.items
+}
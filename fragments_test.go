@@ -0,0 +1,48 @@
+package markparsr
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExtractAnchors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "id and name attributes",
+			body: `<h1 id="intro">Intro</h1><a name="legacy"></a><div ID=bare class="x"></div>`,
+			want: []string{"intro", "legacy", "bare"},
+		},
+		{
+			name: "single quotes and entities",
+			body: `<span id='a&amp;b'></span>`,
+			want: []string{"a&b"},
+		},
+		{
+			name: "github heading prefix",
+			body: `<h2 id="user-content-usage">Usage</h2>`,
+			want: []string{"user-content-usage", "usage"},
+		},
+		{
+			name: "ignores comments and similar attributes",
+			body: `<!-- <div id="hidden"></div> --><div data-id="nope" grid="x"></div><p>id="text"</p>`,
+			want: nil,
+		},
+		{
+			name: "duplicates",
+			body: `<a id="x"></a><a name="x"></a>`,
+			want: []string{"x"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractAnchors([]byte(tt.body)); !slices.Equal(got, tt.want) {
+				t.Errorf("extractAnchors() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package markparsr
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -25,6 +26,7 @@ type normalizedItem struct {
 type itemIndex struct {
 	entries map[string]normalizedItem
 	byBase  map[string][]normalizedItem
+	order   []string
 }
 
 func buildItemIndex(items []string) itemIndex {
@@ -62,6 +64,7 @@ func buildItemIndex(items []string) itemIndex {
 
 		index.entries[key] = entry
 		index.byBase[base] = append(index.byBase[base], entry)
+		index.order = append(index.order, key)
 	}
 
 	for key, entry := range index.entries {
@@ -80,8 +83,10 @@ func buildItemIndex(items []string) itemIndex {
 
 func (idx itemIndex) items() []normalizedItem {
 	result := make([]normalizedItem, 0, len(idx.entries))
-	for _, entry := range idx.entries {
-		result = append(result, entry)
+	for _, key := range idx.order {
+		if entry, ok := idx.entries[key]; ok {
+			result = append(result, entry)
+		}
 	}
 	return result
 }
@@ -106,15 +111,60 @@ func compareTerraformAndMarkdown(tfItems, mdItems []string, itemType string) []e
 		if mdIndex.hasMatch(entry) {
 			continue
 		}
-		errors = append(errors, fmt.Errorf("%s in Terraform but missing in markdown: %s", itemType, entry.original))
+		errors = append(errors, newItemFinding(itemType, entry.original, "%s in Terraform but missing in markdown: %s"))
 	}
 
 	for _, entry := range mdIndex.items() {
 		if tfIndex.hasMatch(entry) {
 			continue
 		}
-		errors = append(errors, fmt.Errorf("%s in markdown but missing in Terraform: %s", itemType, entry.original))
+		errors = append(errors, newItemFinding(itemType, entry.original, "%s in markdown but missing in Terraform: %s"))
 	}
 
 	return errors
 }
+
+func newItemFinding(itemType, item, format string) *Finding {
+	return &Finding{
+		Severity: SeverityError,
+		Item:     item,
+		Message:  fmt.Sprintf(format, itemType, item),
+	}
+}
+
+// locateItemFindings points each item finding at the Terraform block that
+// declares the item or, when there is none, at its first mention in the
+// README found with the given needle formats.
+func locateItemFindings(errs []error, blocks []*TerraformBlock, markdown *MarkdownContent, needles ...string) {
+	for _, err := range errs {
+		var finding *Finding
+		if !errors.As(err, &finding) || finding.Item == "" || finding.Location.File != "" {
+			continue
+		}
+
+		if block := findItemBlock(blocks, finding.Item); block != nil {
+			finding.Location = rangeLocation(block.DeclRange)
+			continue
+		}
+
+		candidates := make([]string, 0, len(needles)+1)
+		for _, needle := range needles {
+			candidates = append(candidates, fmt.Sprintf(needle, finding.Item))
+		}
+		finding.Location = markdown.location(append(candidates, finding.Item)...)
+	}
+}
+
+func findItemBlock(blocks []*TerraformBlock, item string) *TerraformBlock {
+	for _, block := range blocks {
+		if strings.EqualFold(block.Name(), item) {
+			return block
+		}
+	}
+	for _, block := range blocks {
+		if len(block.Labels) > 1 && strings.EqualFold(block.Labels[0], item) {
+			return block
+		}
+	}
+	return nil
+}
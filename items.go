@@ -7,6 +7,7 @@ type ItemValidator struct {
 	blockType string
 	sections  []string
 	fileName  string
+	ignore    ItemIgnores
 }
 
 func NewItemValidator(markdown *MarkdownContent, terraform *TerraformContent, itemType, blockType string, sections []string, fileName string) *ItemValidator {
@@ -35,9 +36,20 @@ func (iv *ItemValidator) Validate() []error {
 		mdItems = append(mdItems, iv.markdown.ExtractSectionItems(section)...)
 	}
 
+	tfItems = iv.ignore.filter(iv.blockType, tfItems)
+	mdItems = iv.ignore.filter(iv.blockType, mdItems)
+
 	if !sectionPresent && len(mdItems) == 0 && len(tfItems) == 0 {
 		return nil
 	}
 
-	return compareTerraformAndMarkdown(tfItems, mdItems, iv.itemType)
+	errs := compareTerraformAndMarkdown(tfItems, mdItems, iv.itemType)
+	if blocks, err := iv.terraform.ExtractModuleBlocks(iv.blockType); err == nil {
+		anchor := "input"
+		if iv.blockType == "output" {
+			anchor = "output"
+		}
+		locateItemFindings(errs, blocks, iv.markdown, `name="`+anchor+`_%s"`, "### %s")
+	}
+	return errs
 }
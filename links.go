@@ -0,0 +1,146 @@
+package markparsr
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gomarkdown/markdown/ast"
+)
+
+type RelativeLinkValidator struct {
+	content   *MarkdownContent
+	readmeDir string
+	readme    string
+	anchors   map[string]map[string]bool
+}
+
+func NewRelativeLinkValidator(content *MarkdownContent, readmePath string) *RelativeLinkValidator {
+	return &RelativeLinkValidator{
+		content:   content,
+		readmeDir: filepath.Dir(readmePath),
+		readme:    readmePath,
+		anchors:   make(map[string]map[string]bool),
+	}
+}
+
+type markdownLink struct {
+	destination string
+	image       bool
+}
+
+func (rlv *RelativeLinkValidator) Validate() []error {
+	var links []markdownLink
+	ast.WalkFunc(rlv.content.rootNode, func(node ast.Node, entering bool) ast.WalkStatus {
+		if !entering {
+			return ast.GoToNext
+		}
+		switch n := node.(type) {
+		case *ast.Link:
+			links = append(links, markdownLink{destination: string(n.Destination)})
+		case *ast.Image:
+			links = append(links, markdownLink{destination: string(n.Destination), image: true})
+		}
+		return ast.GoToNext
+	})
+
+	var errors []error
+	for _, link := range links {
+		if err := rlv.validateLink(link); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	return errors
+}
+
+func (rlv *RelativeLinkValidator) validateLink(link markdownLink) error {
+	destination := strings.TrimSpace(link.destination)
+	if destination == "" {
+		return nil
+	}
+
+	parsed, err := url.Parse(destination)
+	if err != nil {
+		return rlv.finding(destination, "invalid link: %s: %v", destination, err)
+	}
+
+	// Absolute URLs belong to URLValidator, and root-relative paths depend on
+	// the repository layout, which is unknown here.
+	if parsed.Scheme != "" || parsed.Host != "" || strings.HasPrefix(parsed.Path, "/") {
+		return nil
+	}
+
+	if parsed.Path == "" {
+		if link.image || parsed.Fragment == "" {
+			return nil
+		}
+		if !rlv.fileAnchors(rlv.readme)[strings.ToLower(parsed.Fragment)] {
+			return rlv.finding(destination, "link fragment not found in README: #%s", parsed.Fragment)
+		}
+		return nil
+	}
+
+	target := filepath.Join(rlv.readmeDir, filepath.FromSlash(parsed.Path))
+	info, err := os.Stat(target)
+	if err != nil {
+		kind := "link"
+		if link.image {
+			kind = "image"
+		}
+		if os.IsNotExist(err) {
+			return rlv.finding(destination, "relative %s target does not exist: %s", kind, parsed.Path)
+		}
+		return rlv.finding(destination, "error accessing relative %s target: %s: %v", kind, parsed.Path, err)
+	}
+
+	if link.image || parsed.Fragment == "" || info.IsDir() || !isMarkdownFile(target) {
+		return nil
+	}
+
+	if !rlv.fileAnchors(target)[strings.ToLower(parsed.Fragment)] {
+		return rlv.finding(destination, "link fragment not found in %s: #%s", parsed.Path, parsed.Fragment)
+	}
+	return nil
+}
+
+func (rlv *RelativeLinkValidator) fileAnchors(path string) map[string]bool {
+	if anchors, ok := rlv.anchors[path]; ok {
+		return anchors
+	}
+
+	content := rlv.content
+	if path != rlv.readme {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		content = NewMarkdownContent(string(data), FormatDocument, nil)
+	}
+
+	anchors := content.anchors()
+	rlv.anchors[path] = anchors
+	return anchors
+}
+
+// finding locates destination at its inline link or, for reference-style
+// links and images, at the line of the reference definition.
+func (rlv *RelativeLinkValidator) finding(destination, format string, args ...any) *Finding {
+	location := rlv.content.location("("+destination, "]: "+destination, "]:"+destination)
+	return &Finding{
+		Rule:     RuleRelativeLinks,
+		Severity: SeverityError,
+		Location: Location{File: rlv.readme, Line: location.Line},
+		Item:     destination,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+func isMarkdownFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		return true
+	}
+	return false
+}
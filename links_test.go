@@ -0,0 +1,122 @@
+package markparsr
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRelativeLinkValidator_Validate(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.MkdirAll(filepath.Join(tmpDir, "docs"), 0o755)
+	os.MkdirAll(filepath.Join(tmpDir, "modules", "subnet"), 0o755)
+	os.WriteFile(filepath.Join(tmpDir, "GOALS.md"), []byte("# Goals\n\n## Design Principles\n\n## Usage\n\n## Usage\n"), 0o644)
+	os.WriteFile(filepath.Join(tmpDir, "docs", "arch.png"), []byte("png"), 0o644)
+	os.WriteFile(filepath.Join(tmpDir, "modules", "subnet", "README.md"), []byte("# Subnet\n"), 0o644)
+	os.WriteFile(filepath.Join(tmpDir, "LICENSE"), []byte("MIT"), 0o644)
+
+	readmePath := filepath.Join(tmpDir, "examples", "README.md")
+	os.MkdirAll(filepath.Dir(readmePath), 0o755)
+
+	tests := []struct {
+		name     string
+		markdown string
+		errors   []string
+	}{
+		{
+			name:     "existing targets",
+			markdown: "[goals](../GOALS.md)\n\n![diagram](../docs/arch.png)\n\n[subnet](../modules/subnet/README.md)\n\n[license](../LICENSE)\n\n[docs](../docs)",
+		},
+		{
+			name:     "missing link and image",
+			markdown: "[goals](GOALS.md)\n\n![diagram](../docs/missing.png)",
+			errors:   []string{"relative link target does not exist: GOALS.md", "relative image target does not exist: ../docs/missing.png"},
+		},
+		{
+			name:     "fragments in target markdown",
+			markdown: "[principles](../GOALS.md#design-principles)\n[second usage](../GOALS.md#usage-1)\n[gone](../GOALS.md#removed)",
+			errors:   []string{"link fragment not found in ../GOALS.md: #removed"},
+		},
+		{
+			name:     "fragments in the readme itself",
+			markdown: "## Inputs\n\n<a name=\"input_name\"></a>\n\n[name](#input\\_name) [inputs](#inputs) [missing](#outputs)",
+			errors:   []string{"link fragment not found in README: #outputs"},
+		},
+		{
+			name:     "absolute and root-relative links are skipped",
+			markdown: "[site](https://example.com/missing.md) [mail](mailto:team@example.com) [root](/docs/missing.md) ![badge](https://img.shields.io/x.svg)",
+		},
+		{
+			name:     "escaped path and query",
+			markdown: "[goals](../GOALS%2Emd?plain=1)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := NewMarkdownContent(tt.markdown, FormatDocument, nil)
+			errs := NewRelativeLinkValidator(mc, readmePath).Validate()
+
+			if len(errs) != len(tt.errors) {
+				t.Fatalf("Validate() returned %d errors; want %d: %v", len(errs), len(tt.errors), errs)
+			}
+			for i, want := range tt.errors {
+				if !strings.Contains(errs[i].Error(), want) {
+					t.Errorf("errs[%d] = %v; want it to contain %q", i, errs[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestRelativeLinkValidator_Location(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+	}{
+		{"inline", "# Module\n\nSee the\n[goals](GOALS.md) file.\n", 4},
+		{"reference", "# Module\n\nSee the [goals][g] file.\n\n[g]: GOALS.md\n", 5},
+		{"reference image", "# Module\n\n![diagram][d]\n\n[d]: docs/diagram.png\n", 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readmePath := filepath.Join(t.TempDir(), "README.md")
+			mc := NewMarkdownContent(tt.content, FormatDocument, nil)
+
+			errs := NewRelativeLinkValidator(mc, readmePath).Validate()
+			if len(errs) != 1 {
+				t.Fatalf("Validate() returned %d errors; want 1", len(errs))
+			}
+
+			var finding *Finding
+			if !errors.As(errs[0], &finding) {
+				t.Fatalf("Validate() error = %T; want *Finding", errs[0])
+			}
+			if finding.Rule != "relative-links" || finding.Location.File != readmePath || finding.Location.Line != tt.want {
+				t.Errorf("finding = %+v; want relative-links at README.md:%d", finding, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeadingSlug(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "Required Inputs", want: "required-inputs"},
+		{text: "  What's new?  ", want: "whats-new"},
+		{text: "resource_group_name", want: "resource_group_name"},
+		{text: "Café & Co.", want: "café--co"},
+	}
+
+	for _, tt := range tests {
+		if got := headingSlug(tt.text); got != tt.want {
+			t.Errorf("headingSlug(%q) = %q; want %q", tt.text, got, tt.want)
+		}
+	}
+}
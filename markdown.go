@@ -4,9 +4,9 @@ package markparsr
 import (
 	"fmt"
 	"regexp"
-	"slices"
 	"strings"
 	"sync"
+	"unicode"
 
 	"github.com/gomarkdown/markdown"
 	"github.com/gomarkdown/markdown/ast"
@@ -25,6 +25,8 @@ var (
 )
 
 type MarkdownContent struct {
+	mu               sync.RWMutex
+	path             string
 	data             string
 	rootNode         ast.Node
 	sections         map[string]bool
@@ -34,6 +36,7 @@ type MarkdownContent struct {
 	h2Headings       []*ast.Heading
 	sectionNames     []string
 	sectionMatches   map[string][]*ast.Heading
+	sectionBodies    map[*ast.Heading][]ast.Node
 	anchorTypes      map[string]map[string]bool
 }
 
@@ -56,6 +59,7 @@ func NewMarkdownContent(data string, format MarkdownFormat, providerPrefixes []s
 	}
 
 	mc.indexHeadings()
+	mc.indexSections()
 	mc.indexAnchors()
 
 	mc.format = FormatDocument
@@ -85,6 +89,46 @@ func (mc *MarkdownContent) indexHeadings() {
 	mc.sectionNames = names
 }
 
+// indexSections records, for every H2 heading, the run of siblings that form
+// its section body, so section lookups never rescan the parent's children.
+func (mc *MarkdownContent) indexSections() {
+	mc.sectionBodies = make(map[*ast.Heading][]ast.Node, len(mc.h2Headings))
+	indexed := make(map[ast.Node]bool)
+
+	for _, heading := range mc.h2Headings {
+		parent := heading.GetParent()
+		if parent == nil || indexed[parent] {
+			continue
+		}
+		indexed[parent] = true
+
+		children := parent.GetChildren()
+		var current *ast.Heading
+		start := 0
+		for i, child := range children {
+			h, ok := child.(*ast.Heading)
+			if !ok || h.Level > 2 {
+				continue
+			}
+			if current != nil {
+				mc.sectionBodies[current] = children[start:i]
+			}
+			current = nil
+			if h.Level == 2 {
+				current = h
+				start = i + 1
+			}
+		}
+		if current != nil {
+			mc.sectionBodies[current] = children[start:]
+		}
+	}
+}
+
+func (mc *MarkdownContent) sectionBody(heading *ast.Heading) []ast.Node {
+	return mc.sectionBodies[heading]
+}
+
 func (mc *MarkdownContent) indexAnchors() {
 	mc.anchorTypes = make(map[string]map[string]bool)
 	type anchorDef struct {
@@ -114,17 +158,75 @@ func (mc *MarkdownContent) indexAnchors() {
 	}
 }
 
+// anchors returns the fragments a renderer such as GitHub generates for the
+// document, keyed in lower case: a slug per heading, numbered when repeated,
+// plus explicit HTML id and name attributes.
+func (mc *MarkdownContent) anchors() map[string]bool {
+	anchors := make(map[string]bool)
+	seen := make(map[string]int)
+
+	ast.WalkFunc(mc.rootNode, func(node ast.Node, entering bool) ast.WalkStatus {
+		if !entering {
+			return ast.GoToNext
+		}
+		if heading, ok := node.(*ast.Heading); ok {
+			slug := headingSlug(mc.extractText(heading))
+			if n := seen[slug]; n > 0 {
+				anchors[fmt.Sprintf("%s-%d", slug, n)] = true
+			} else {
+				anchors[slug] = true
+			}
+			seen[slug]++
+			return ast.SkipChildren
+		}
+		return ast.GoToNext
+	})
+
+	for _, anchor := range extractAnchors([]byte(mc.data)) {
+		anchors[strings.ToLower(anchor)] = true
+	}
+
+	return anchors
+}
+
+// lineOf returns the 1-based line of the first occurrence of s in the
+// document, or 0 when it does not appear verbatim.
+func (mc *MarkdownContent) lineOf(s string) int {
+	idx := strings.Index(mc.data, s)
+	if idx < 0 {
+		return 0
+	}
+	return strings.Count(mc.data[:idx], "\n") + 1
+}
+
+// location returns the README position of the first needle that appears in
+// the document.
+func (mc *MarkdownContent) location(needles ...string) Location {
+	for _, needle := range needles {
+		if line := mc.lineOf(needle); line > 0 {
+			return Location{File: mc.path, Line: line}
+		}
+	}
+	return Location{File: mc.path}
+}
+
 func (mc *MarkdownContent) GetContent() string {
 	return mc.data
 }
 
 func (mc *MarkdownContent) HasSection(sectionName string) bool {
-	if found, exists := mc.sections[sectionName]; exists {
+	mc.mu.RLock()
+	found, exists := mc.sections[sectionName]
+	mc.mu.RUnlock()
+	if exists {
 		return found
 	}
 
-	found := len(mc.matchExactSectionHeadings(sectionName)) > 0
+	found = len(mc.matchExactSectionHeadings(sectionName)) > 0
+
+	mc.mu.Lock()
 	mc.sections[sectionName] = found
+	mc.mu.Unlock()
 	return found
 }
 
@@ -159,7 +261,10 @@ func (mc *MarkdownContent) matchSectionHeadings(sectionName string) []*ast.Headi
 		return nil
 	}
 
-	if cached, ok := mc.sectionMatches[key]; ok {
+	mc.mu.RLock()
+	cached, ok := mc.sectionMatches[key]
+	mc.mu.RUnlock()
+	if ok {
 		return cached
 	}
 
@@ -171,7 +276,9 @@ func (mc *MarkdownContent) matchSectionHeadings(sectionName string) []*ast.Headi
 		}
 	}
 
+	mc.mu.Lock()
 	mc.sectionMatches[key] = matches
+	mc.mu.Unlock()
 	return matches
 }
 
@@ -301,17 +408,11 @@ func (mc *MarkdownContent) extractAnchoredItems(re *regexp.Regexp) []string {
 
 func (mc *MarkdownContent) itemsUnderHeading(heading *ast.Heading) []string {
 	var items []string
-	for node := getNextSibling(heading); node != nil; node = getNextSibling(node) {
-		if h, ok := node.(*ast.Heading); ok {
-			if h.Level <= heading.Level {
-				break
-			}
-			if h.Level == 3 {
-				if name, ok := mc.itemNameFromHeading(h); ok {
-					items = append(items, name)
-				}
+	for _, node := range mc.sectionBody(heading) {
+		if h, ok := node.(*ast.Heading); ok && h.Level == 3 {
+			if name, ok := mc.itemNameFromHeading(h); ok {
+				items = append(items, name)
 			}
-			continue
 		}
 	}
 	return items
@@ -336,14 +437,10 @@ func (mc *MarkdownContent) itemNameFromHeading(heading *ast.Heading) (string, bo
 }
 
 func (mc *MarkdownContent) resourcesUnderHeading(heading *ast.Heading) ([]string, []string) {
-	var resources []string
-	var dataSources []string
-
-	for node := getNextSibling(heading); node != nil; node = getNextSibling(node) {
-		if h, ok := node.(*ast.Heading); ok && h.Level <= heading.Level {
-			break
-		}
+	var resources uniqueList
+	var dataSources uniqueList
 
+	for _, node := range mc.sectionBody(heading) {
 		ast.WalkFunc(node, func(n ast.Node, entering bool) ast.WalkStatus {
 			if !entering {
 				return ast.GoToNext
@@ -356,12 +453,12 @@ func (mc *MarkdownContent) resourcesUnderHeading(heading *ast.Heading) ([]string
 		})
 	}
 
-	return resources, dataSources
+	return resources.items, dataSources.items
 }
 
 func (mc *MarkdownContent) resourcesWithoutHeading() ([]string, []string) {
-	var resources []string
-	var dataSources []string
+	var resources uniqueList
+	var dataSources uniqueList
 
 	ast.WalkFunc(mc.rootNode, func(n ast.Node, entering bool) ast.WalkStatus {
 		if !entering {
@@ -374,10 +471,10 @@ func (mc *MarkdownContent) resourcesWithoutHeading() ([]string, []string) {
 		return ast.GoToNext
 	})
 
-	return resources, dataSources
+	return resources.items, dataSources.items
 }
 
-func (mc *MarkdownContent) appendResourceFromLink(link *ast.Link, resources, dataSources *[]string) {
+func (mc *MarkdownContent) appendResourceFromLink(link *ast.Link, resources, dataSources *uniqueList) {
 	linkText := mc.extractText(link)
 	destination := string(link.Destination)
 	if !mc.hasProviderPrefix(linkText) {
@@ -389,11 +486,11 @@ func (mc *MarkdownContent) appendResourceFromLink(link *ast.Link, resources, dat
 	baseName := strings.Split(resourceName, ".")[0]
 
 	if strings.Contains(destination, "/data-sources/") {
-		addUnique(dataSources, resourceName)
-		addUnique(dataSources, baseName)
+		dataSources.add(resourceName)
+		dataSources.add(baseName)
 	} else {
-		addUnique(resources, resourceName)
-		addUnique(resources, baseName)
+		resources.add(resourceName)
+		resources.add(baseName)
 	}
 }
 
@@ -462,24 +559,33 @@ func (mc *MarkdownContent) hasProviderPrefix(s string) bool {
 	return false
 }
 
-func getNextSibling(node ast.Node) ast.Node {
-	parent := node.GetParent()
-	if parent == nil {
-		return nil
+type uniqueList struct {
+	items []string
+	seen  map[string]struct{}
+}
+
+func (ul *uniqueList) add(item string) {
+	if ul.seen == nil {
+		ul.seen = make(map[string]struct{})
 	}
-	children := parent.GetChildren()
-	for i, n := range children {
-		if n == node && i+1 < len(children) {
-			return children[i+1]
-		}
+	if _, ok := ul.seen[item]; ok {
+		return
 	}
-	return nil
+	ul.seen[item] = struct{}{}
+	ul.items = append(ul.items, item)
 }
 
-func addUnique(slice *[]string, item string) {
-	if !slices.Contains(*slice, item) {
-		*slice = append(*slice, item)
+func headingSlug(text string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			sb.WriteRune(r)
+		case unicode.IsSpace(r):
+			sb.WriteRune('-')
+		}
 	}
+	return sb.String()
 }
 
 func matchesSectionName(actual, expected string) bool {
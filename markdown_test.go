@@ -1,9 +1,13 @@
 package markparsr
 
 import (
+	"fmt"
 	"slices"
 	"strings"
+	"sync"
 	"testing"
+
+	"github.com/gomarkdown/markdown/ast"
 )
 
 func TestNewMarkdownContent(t *testing.T) {
@@ -222,6 +226,117 @@ Description
 	}
 }
 
+func TestMarkdownContent_IndexSections(t *testing.T) {
+	data := `# Module
+
+## Required Inputs
+
+### <a name="input_var1"></a> var1
+
+Description
+
+## Outputs
+
+### <a name="output_id"></a> id
+
+# Appendix
+
+### not_an_output
+`
+	mc := NewMarkdownContent(data, FormatDocument, nil)
+
+	if len(mc.sectionBodies) != 2 {
+		t.Fatalf("indexSections() indexed %d sections; want 2", len(mc.sectionBodies))
+	}
+
+	for _, heading := range mc.h2Headings {
+		body := mc.sectionBody(heading)
+		for _, node := range body {
+			if h, ok := node.(*ast.Heading); ok && h.Level <= 2 {
+				t.Errorf("section %q body contains heading of level %d", mc.extractText(heading), h.Level)
+			}
+		}
+	}
+
+	if items := mc.ExtractSectionItems("Outputs"); !slices.Equal(items, []string{"id"}) {
+		t.Errorf("ExtractSectionItems(Outputs) = %v; want [id]", items)
+	}
+}
+
+func TestMarkdownContent_ConcurrentReads(t *testing.T) {
+	mc := NewMarkdownContent(generateLargeReadme(50), FormatDocument, []string{"azurerm_"})
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, section := range []string{"Resources", "Required Inputs", "Outputs", "Missing"} {
+				mc.HasSection(section)
+				mc.ExtractSectionItems(section)
+			}
+			mc.ExtractResourcesAndDataSources()
+		}()
+	}
+	wg.Wait()
+
+	if !mc.HasSection("Outputs") || mc.HasSection("Missing") {
+		t.Error("HasSection() cache returned unexpected results after concurrent reads")
+	}
+}
+
+func generateLargeReadme(items int) string {
+	var sb strings.Builder
+	sb.WriteString("# Module\n\n## Resources\n\n")
+	for i := range items {
+		fmt.Fprintf(&sb, "- [azurerm_resource_%d.main](https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/resources/resource_%d) (resource)\n", i, i)
+	}
+	sb.WriteString("\n## Required Inputs\n\n")
+	for i := range items {
+		fmt.Fprintf(&sb, "### <a name=\"input_var%d\"></a> [var%d](#input\\_var%d)\n\nDescription: Variable %d\n\nType: `string`\n\n", i, i, i, i)
+	}
+	sb.WriteString("## Outputs\n\n")
+	for i := range items {
+		fmt.Fprintf(&sb, "### <a name=\"output_out%d\"></a> [out%d](#output\\_out%d)\n\nDescription: Output %d\n\n", i, i, i, i)
+	}
+	return sb.String()
+}
+
+func BenchmarkMarkdownContent_ExtractSectionItems(b *testing.B) {
+	for _, size := range []int{100, 1000, 5000} {
+		data := generateLargeReadme(size)
+		b.Run(fmt.Sprintf("items=%d", size), func(b *testing.B) {
+			mc := NewMarkdownContent(data, FormatDocument, []string{"azurerm_"})
+			for b.Loop() {
+				mc.ExtractSectionItems("Required Inputs", "Optional Inputs")
+			}
+		})
+	}
+}
+
+func BenchmarkMarkdownContent_ExtractResourcesAndDataSources(b *testing.B) {
+	for _, size := range []int{100, 1000, 5000} {
+		data := generateLargeReadme(size)
+		b.Run(fmt.Sprintf("items=%d", size), func(b *testing.B) {
+			mc := NewMarkdownContent(data, FormatDocument, []string{"azurerm_"})
+			for b.Loop() {
+				mc.ExtractResourcesAndDataSources()
+			}
+		})
+	}
+}
+
+func BenchmarkNewMarkdownContent(b *testing.B) {
+	for _, size := range []int{100, 1000, 5000} {
+		data := generateLargeReadme(size)
+		b.Run(fmt.Sprintf("items=%d", size), func(b *testing.B) {
+			for b.Loop() {
+				NewMarkdownContent(data, FormatDocument, []string{"azurerm_"})
+			}
+		})
+	}
+}
+
 func TestMarkdownContent_ExtractResourcesAndDataSources(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -530,7 +645,7 @@ func TestMarkdownContent_IndexAnchors(t *testing.T) {
 	}
 }
 
-func TestAddUnique(t *testing.T) {
+func TestUniqueList_Add(t *testing.T) {
 	tests := []struct {
 		name          string
 		initial       []string
@@ -563,13 +678,17 @@ func TestAddUnique(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			slice := tt.initial
+			var list uniqueList
+			for _, item := range tt.initial {
+				list.add(item)
+			}
 			for _, item := range tt.toAdd {
-				addUnique(&slice, item)
+				list.add(item)
 			}
+			slice := list.items
 
 			if len(slice) != tt.expectedCount {
-				t.Errorf("addUnique resulted in %d items; want %d", len(slice), tt.expectedCount)
+				t.Errorf("add resulted in %d items; want %d", len(slice), tt.expectedCount)
 			}
 
 			for _, expected := range tt.expectedItems {
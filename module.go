@@ -0,0 +1,113 @@
+package markparsr
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var moduleBlockTypes = []string{"terraform", "provider", "variable", "output", "module", "resource", "data"}
+
+type TerraformModule struct {
+	Variables   []*TerraformBlock
+	Outputs     []*TerraformBlock
+	Resources   []*TerraformBlock
+	DataSources []*TerraformBlock
+	ModuleCalls []*TerraformBlock
+	Providers   []*TerraformBlock
+	Terraform   []*TerraformBlock
+	Diagnostics hcl.Diagnostics
+}
+
+func newTerraformModule(blocks []*TerraformBlock, diags hcl.Diagnostics) *TerraformModule {
+	module := &TerraformModule{Diagnostics: diags}
+
+	for _, block := range blocks {
+		switch block.Type {
+		case "variable":
+			module.Variables = append(module.Variables, block)
+		case "output":
+			module.Outputs = append(module.Outputs, block)
+		case "resource":
+			module.Resources = append(module.Resources, block)
+		case "data":
+			module.DataSources = append(module.DataSources, block)
+		case "module":
+			module.ModuleCalls = append(module.ModuleCalls, block)
+		case "provider":
+			module.Providers = append(module.Providers, block)
+		case "terraform":
+			module.Terraform = append(module.Terraform, block)
+		}
+	}
+
+	return module
+}
+
+func (m *TerraformModule) Blocks(blockType string) []*TerraformBlock {
+	switch blockType {
+	case "variable":
+		return m.Variables
+	case "output":
+		return m.Outputs
+	case "resource":
+		return m.Resources
+	case "data":
+		return m.DataSources
+	case "module":
+		return m.ModuleCalls
+	case "provider":
+		return m.Providers
+	case "terraform":
+		return m.Terraform
+	default:
+		return nil
+	}
+}
+
+// RequiredProviders maps each local provider name declared in
+// required_providers to its source address in namespace/type form, expanding
+// the implied hashicorp namespace and dropping the public registry host.
+func (m *TerraformModule) RequiredProviders() map[string]string {
+	providers := make(map[string]string)
+
+	for _, settings := range m.Terraform {
+		for _, block := range settings.Blocks {
+			if block.Type != "required_providers" {
+				continue
+			}
+			attrs, _ := block.Body.JustAttributes()
+			for name, attr := range attrs {
+				providers[name] = providerSource(name, attr)
+			}
+		}
+	}
+
+	return providers
+}
+
+func providerSource(name string, attr *hcl.Attribute) string {
+	source := "hashicorp/" + name
+
+	value, diags := attr.Expr.Value(nil)
+	if !diags.HasErrors() && value.IsKnown() && !value.IsNull() && value.Type().IsObjectType() && value.Type().HasAttribute("source") {
+		if src := value.GetAttr("source"); src.IsKnown() && !src.IsNull() && src.Type().Equals(cty.String) {
+			source = src.AsString()
+		}
+	}
+
+	source = strings.TrimPrefix(strings.ToLower(source), "registry.terraform.io/")
+	if !strings.Contains(source, "/") {
+		source = "hashicorp/" + source
+	}
+	return source
+}
+
+func moduleSchemas() []hcl.BlockHeaderSchema {
+	schemas := make([]hcl.BlockHeaderSchema, 0, len(moduleBlockTypes))
+	for _, blockType := range moduleBlockTypes {
+		schemas = append(schemas, hcl.BlockHeaderSchema{Type: blockType, LabelNames: blockLabelNames(blockType)})
+	}
+	return schemas
+}
@@ -0,0 +1,244 @@
+package markparsr
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+type countingFileReader struct {
+	reads map[string]int
+}
+
+func (c *countingFileReader) ReadFile(path string) ([]byte, error) {
+	c.reads[filepath.Base(path)]++
+	return os.ReadFile(path)
+}
+
+func TestTerraformContent_Module(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"terraform.tf": `
+terraform {
+  required_version = ">= 1.9.0"
+
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> 4.0"
+    }
+  }
+}
+
+provider "azurerm" {
+  features {}
+}
+
+provider "azurerm" {
+  alias = "west"
+  features {}
+}
+`,
+		"variables.tf": `
+variable "name" {
+  description = "The name"
+  type        = string
+}
+`,
+		"outputs.tf": `
+output "id" {
+  value = azurerm_resource_group.main.id
+}
+`,
+		"main.tf": `
+resource "azurerm_resource_group" "main" {
+  name = var.name
+}
+
+data "azurerm_client_config" "current" {}
+
+module "naming" {
+  source = "cloudnationhq/naming/azure"
+}
+`,
+		"providers_override.tf": `
+provider "azurerm" {
+  alias                      = "west"
+  skip_provider_registration = true
+}
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	reader := &countingFileReader{reads: make(map[string]int)}
+	tc, _ := NewTerraformContent(dir)
+	tc.fileReader = reader
+
+	module, err := tc.Module()
+	if err != nil {
+		t.Fatalf("Module() error = %v", err)
+	}
+
+	counts := map[string]int{
+		"variable":  len(module.Variables),
+		"output":    len(module.Outputs),
+		"resource":  len(module.Resources),
+		"data":      len(module.DataSources),
+		"module":    len(module.ModuleCalls),
+		"provider":  len(module.Providers),
+		"terraform": len(module.Terraform),
+	}
+	want := map[string]int{
+		"variable":  1,
+		"output":    1,
+		"resource":  1,
+		"data":      1,
+		"module":    1,
+		"provider":  2,
+		"terraform": 1,
+	}
+	for blockType, count := range want {
+		if counts[blockType] != count {
+			t.Errorf("Module() has %d %s blocks; want %d", counts[blockType], blockType, count)
+		}
+	}
+
+	for _, provider := range module.Providers {
+		alias, _ := provider.StringAttribute("alias")
+		_, skip := provider.Attributes["skip_provider_registration"]
+		if skip != (alias == "west") {
+			t.Errorf("provider %q override applied = %v", alias, skip)
+		}
+	}
+
+	if module.Variables[0].DeclRange.Start.Line != 2 {
+		t.Errorf("variable DeclRange line = %d; want 2", module.Variables[0].DeclRange.Start.Line)
+	}
+
+	tc.ExtractModuleItems("variable")
+	tc.ExtractModuleItems("output")
+	tc.ExtractResourcesAndDataSources()
+	tc.Diagnostics()
+
+	for name, count := range reader.reads {
+		if count != 1 {
+			t.Errorf("%s read %d times; want 1", name, count)
+		}
+	}
+	if len(reader.reads) != len(files) {
+		t.Errorf("read %d files; want %d", len(reader.reads), len(files))
+	}
+}
+
+func TestTerraformModule_Blocks(t *testing.T) {
+	variable := &TerraformBlock{Type: "variable", Labels: []string{"name"}}
+	resource := &TerraformBlock{Type: "resource", Labels: []string{"azurerm_resource_group", "main"}}
+	module := newTerraformModule([]*TerraformBlock{variable, resource}, nil)
+
+	if got := module.Blocks("variable"); len(got) != 1 || got[0] != variable {
+		t.Errorf("Blocks(variable) = %v", got)
+	}
+	if got := module.Blocks("resource"); len(got) != 1 || got[0] != resource {
+		t.Errorf("Blocks(resource) = %v", got)
+	}
+	if got := module.Blocks("locals"); got != nil {
+		t.Errorf("Blocks(locals) = %v; want nil", got)
+	}
+}
+
+func TestTerraformContent_ExtractOtherBlockTypes(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`locals {
+  a = 1
+}
+
+check "health" {
+  assert {
+    condition     = true
+    error_message = "unhealthy"
+  }
+}
+
+variable "name" {}
+`), 0o644)
+	os.WriteFile(filepath.Join(dir, "locals.tf"), []byte("locals {\n  b = 2\n}\n"), 0o644)
+
+	tc, _ := NewTerraformContent(dir)
+
+	items, err := tc.ExtractModuleItems("check")
+	if err != nil || len(items) != 1 || items[0] != "health" {
+		t.Errorf("ExtractModuleItems(check) = %v, %v; want [health]", items, err)
+	}
+
+	blocks, err := tc.ExtractModuleBlocks("locals")
+	if err != nil || len(blocks) != 2 {
+		t.Errorf("ExtractModuleBlocks(locals) returned %d blocks, %v; want 2", len(blocks), err)
+	}
+}
+
+func TestTerraformContent_ModuleConcurrentAccess(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "variables.tf"), []byte(`variable "name" {}`), 0o644)
+
+	reader := &countingFileReader{reads: make(map[string]int)}
+	tc, _ := NewTerraformContent(dir)
+	tc.fileReader = reader
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := tc.ExtractModuleItems("variable"); err != nil {
+				t.Errorf("ExtractModuleItems() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if reader.reads["variables.tf"] != 1 {
+		t.Errorf("variables.tf read %d times; want 1", reader.reads["variables.tf"])
+	}
+}
+
+func TestTerraformModule_RequiredProviders(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "terraform.tf"), []byte(`
+terraform {
+  required_providers {
+    azurerm = {
+      source = "hashicorp/azurerm"
+    }
+    mycloud = {
+      source = "Registry.Terraform.io/Example/MyCloud"
+    }
+    random = {
+      version = "~> 3.0"
+    }
+    legacy = "~> 1.0"
+  }
+}
+`), 0o644)
+
+	tc, _ := NewTerraformContent(tmpDir)
+	module, err := tc.Module()
+	if err != nil {
+		t.Fatalf("Module() error = %v", err)
+	}
+
+	want := map[string]string{
+		"azurerm": "hashicorp/azurerm",
+		"mycloud": "example/mycloud",
+		"random":  "hashicorp/random",
+		"legacy":  "hashicorp/legacy",
+	}
+	if got := module.RequiredProviders(); !maps.Equal(got, want) {
+		t.Errorf("RequiredProviders() = %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,114 @@
+package markparsr
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// compilePattern treats patterns anchored with ^ or $ as regular expressions
+// and anything else as a glob in which * matches any run of characters and ?
+// matches a single character.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "^") || strings.HasSuffix(pattern, "$") {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		return re, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+
+	return regexp.Compile(sb.String())
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := compilePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+func matchesAnyPattern(patterns []*regexp.Regexp, s string) bool {
+	for _, re := range patterns {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+var ignorableBlockTypes = []string{"variable", "output", "resource", "data"}
+
+// ItemIgnores holds the compiled ignore patterns per block type.
+type ItemIgnores map[string][]*regexp.Regexp
+
+// CompileItemIgnores compiles glob or regular expression patterns keyed by
+// variable, output, resource or data, as taken by WithIgnoredItems.
+func CompileItemIgnores(patterns map[string][]string) (ItemIgnores, error) {
+	ignores := make(ItemIgnores, len(patterns))
+	for blockType, list := range patterns {
+		if !slices.Contains(ignorableBlockTypes, blockType) {
+			return nil, fmt.Errorf("unknown block type for ignored items: %q", blockType)
+		}
+		compiled, err := compilePatterns(list)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ignore pattern for %s: %w", blockType, err)
+		}
+		ignores[blockType] = compiled
+	}
+	return ignores, nil
+}
+
+// ignored reports whether item, or for resources and data sources its type,
+// matches an ignore pattern of blockType.
+func (ii ItemIgnores) ignored(blockType, item string) bool {
+	patterns := ii[blockType]
+	if len(patterns) == 0 {
+		return false
+	}
+	base, _, _ := strings.Cut(item, ".")
+	return matchesAnyPattern(patterns, item) || matchesAnyPattern(patterns, base)
+}
+
+func (ii ItemIgnores) filter(blockType string, items []string) []string {
+	if len(ii[blockType]) == 0 {
+		return items
+	}
+	kept := make([]string, 0, len(items))
+	for _, item := range items {
+		if !ii.ignored(blockType, item) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+func (ii ItemIgnores) filterBlocks(blocks []*TerraformBlock) []*TerraformBlock {
+	var kept []*TerraformBlock
+	for _, block := range blocks {
+		if !ii.ignored(block.Type, block.Name()) {
+			kept = append(kept, block)
+		}
+	}
+	return kept
+}
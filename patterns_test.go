@@ -0,0 +1,88 @@
+package markparsr
+
+import "testing"
+
+func TestCompilePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		want    bool
+	}{
+		{pattern: "debug_*", input: "debug_output", want: true},
+		{pattern: "debug_*", input: "my_debug_output", want: false},
+		{pattern: "terraform_data", input: "terraform_data", want: true},
+		{pattern: "terraform_data", input: "terraform_data_source", want: false},
+		{pattern: "*.corp.internal", input: "wiki.corp.internal", want: true},
+		{pattern: "https://example.com/?", input: "https://example.com/a", want: true},
+		{pattern: "https://example.com/?", input: "https://example.com/ab", want: false},
+		{pattern: "^_", input: "_internal", want: true},
+		{pattern: "^_", input: "public", want: false},
+		{pattern: "status/[0-9]+$", input: "https://example.com/status/404", want: true},
+		{pattern: "a.b", input: "axb", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"_"+tt.input, func(t *testing.T) {
+			re, err := compilePattern(tt.pattern)
+			if err != nil {
+				t.Fatalf("compilePattern(%q) error = %v", tt.pattern, err)
+			}
+			if got := re.MatchString(tt.input); got != tt.want {
+				t.Errorf("compilePattern(%q).MatchString(%q) = %v; want %v", tt.pattern, tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompilePatterns_Invalid(t *testing.T) {
+	if _, err := compilePatterns([]string{"valid_*", "^(unclosed"}); err == nil {
+		t.Error("compilePatterns() expected error for invalid regular expression")
+	}
+}
+
+func TestItemIgnores(t *testing.T) {
+	ignore, err := CompileItemIgnores(map[string][]string{
+		"variable": {"^_"},
+		"output":   {"debug_*"},
+		"resource": {"terraform_data"},
+	})
+	if err != nil {
+		t.Fatalf("CompileItemIgnores() error = %v", err)
+	}
+
+	tests := []struct {
+		blockType string
+		item      string
+		want      bool
+	}{
+		{blockType: "variable", item: "_internal", want: true},
+		{blockType: "variable", item: "name", want: false},
+		{blockType: "output", item: "debug_state", want: true},
+		{blockType: "output", item: "_internal", want: false},
+		{blockType: "resource", item: "terraform_data", want: true},
+		{blockType: "resource", item: "terraform_data.replacement", want: true},
+		{blockType: "resource", item: "azurerm_resource_group.terraform_data", want: false},
+		{blockType: "data", item: "terraform_data", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.blockType+"_"+tt.item, func(t *testing.T) {
+			if got := ignore.ignored(tt.blockType, tt.item); got != tt.want {
+				t.Errorf("ignored(%q, %q) = %v; want %v", tt.blockType, tt.item, got, tt.want)
+			}
+		})
+	}
+
+	if got := ignore.filter("variable", []string{"name", "_internal", "location"}); len(got) != 2 || got[0] != "name" || got[1] != "location" {
+		t.Errorf("filter() = %v; want [name location]", got)
+	}
+}
+
+func TestCompileItemIgnores_Invalid(t *testing.T) {
+	if _, err := CompileItemIgnores(map[string][]string{"module": {"*"}}); err == nil {
+		t.Error("CompileItemIgnores() expected error for an unknown block type")
+	}
+	if _, err := CompileItemIgnores(map[string][]string{"variable": {"^(unclosed"}}); err == nil {
+		t.Error("CompileItemIgnores() expected error for an invalid pattern")
+	}
+}
@@ -0,0 +1,125 @@
+package markparsr
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"mvdan.cc/xurls/v2"
+)
+
+type RegistryVersionPolicy string
+
+const (
+	RegistryVersionAny    RegistryVersionPolicy = "any"
+	RegistryVersionLatest RegistryVersionPolicy = "latest"
+	RegistryVersionPinned RegistryVersionPolicy = "pinned"
+)
+
+var (
+	registryNamePattern    = regexp.MustCompile(`(?i)^[a-z0-9][a-z0-9-_]*$`)
+	registryItemPattern    = regexp.MustCompile(`^[a-z0-9][a-z0-9_]*$`)
+	registryVersionPattern = regexp.MustCompile(`^\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?$`)
+	registryDocKinds       = []string{"resources", "data-sources", "ephemeral-resources", "functions", "guides"}
+)
+
+// RegistryLinkValidator checks registry.terraform.io provider documentation
+// links without requesting them: the path must follow
+// providers/namespace/type/version/docs[/kind/name], the provider must be one
+// the module requires and the version must follow the configured policy.
+type RegistryLinkValidator struct {
+	content   *MarkdownContent
+	terraform *TerraformContent
+	policy    RegistryVersionPolicy
+}
+
+func NewRegistryLinkValidator(content *MarkdownContent, terraform *TerraformContent, policy RegistryVersionPolicy) *RegistryLinkValidator {
+	if policy == "" {
+		policy = RegistryVersionAny
+	}
+	return &RegistryLinkValidator{
+		content:   content,
+		terraform: terraform,
+		policy:    policy,
+	}
+}
+
+func (rlv *RegistryLinkValidator) Validate() []error {
+	var links uniqueList
+	for _, link := range xurls.Strict().FindAllString(rlv.content.data, -1) {
+		parsed, err := url.Parse(link)
+		if err != nil || !strings.EqualFold(parsed.Host, "registry.terraform.io") || !strings.HasPrefix(parsed.Path, "/providers/") {
+			continue
+		}
+		links.add(link)
+	}
+	if len(links.items) == 0 {
+		return nil
+	}
+
+	var required []string
+	if module, err := rlv.terraform.Module(); err == nil {
+		for _, source := range module.RequiredProviders() {
+			required = append(required, source)
+		}
+	}
+
+	var errors []error
+	for _, link := range links.items {
+		if err := rlv.validateLink(link, required); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	return errors
+}
+
+func (rlv *RegistryLinkValidator) validateLink(link string, required []string) error {
+	parsed, _ := url.Parse(link)
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(parsed.Path, "/providers/"), "/"), "/")
+
+	if len(segments) < 4 || segments[3] != "docs" {
+		return rlv.finding(link, "malformed registry link: %s: want providers/<namespace>/<type>/<version>/docs/...", link)
+	}
+
+	namespace, provider, version := segments[0], segments[1], segments[2]
+	if !registryNamePattern.MatchString(namespace) || !registryNamePattern.MatchString(provider) {
+		return rlv.finding(link, "malformed registry link: %s: invalid provider address %s/%s", link, namespace, provider)
+	}
+
+	switch docs := segments[4:]; {
+	case len(docs) == 0:
+	case len(docs) != 2 || !slices.Contains(registryDocKinds, docs[0]):
+		return rlv.finding(link, "malformed registry link: %s: want docs/{%s}/<name>", link, strings.Join(registryDocKinds, "|"))
+	case docs[0] != "guides" && !registryItemPattern.MatchString(docs[1]):
+		return rlv.finding(link, "malformed registry link: %s: invalid %s name %q", link, docs[0], docs[1])
+	}
+
+	// Provider addresses are case-insensitive, and required sources are
+	// stored lowercased.
+	if len(required) > 0 && !slices.Contains(required, strings.ToLower(namespace+"/"+provider)) {
+		return rlv.finding(link, "registry link provider %s/%s is not in required_providers: %s", namespace, provider, link)
+	}
+
+	switch {
+	case version != "latest" && !registryVersionPattern.MatchString(version):
+		return rlv.finding(link, "malformed registry link: %s: invalid version %q", link, version)
+	case rlv.policy == RegistryVersionLatest && version != "latest":
+		return rlv.finding(link, "registry link pins version %s, policy requires latest: %s", version, link)
+	case rlv.policy == RegistryVersionPinned && version == "latest":
+		return rlv.finding(link, "registry link uses latest, policy requires a pinned version: %s", link)
+	}
+
+	return nil
+}
+
+func (rlv *RegistryLinkValidator) finding(link, format string, args ...any) *Finding {
+	return &Finding{
+		Rule:     RuleRegistryLinks,
+		Severity: SeverityError,
+		Location: rlv.content.location(link),
+		Item:     link,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,95 @@
+package markparsr
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRegistryLinkValidator_Validate(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "terraform.tf"), []byte(`
+terraform {
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> 4.0"
+    }
+    random = {
+      source = "registry.terraform.io/hashicorp/random"
+    }
+    azapi = {
+      source = "Azure/azapi"
+    }
+  }
+}
+`), 0o644)
+	tc, _ := NewTerraformContent(tmpDir)
+
+	const base = "https://registry.terraform.io/providers/"
+
+	tests := []struct {
+		name   string
+		link   string
+		policy RegistryVersionPolicy
+		error  string
+	}{
+		{name: "resource", link: base + "hashicorp/azurerm/latest/docs/resources/subnet"},
+		{name: "data source", link: base + "hashicorp/random/3.6.0/docs/data-sources/id"},
+		{name: "mixed case namespace", link: base + "Azure/azapi/latest/docs/resources/azapi_resource"},
+		{name: "lowercase namespace", link: base + "azure/azapi/latest/docs/resources/azapi_resource"},
+		{name: "provider index", link: base + "hashicorp/azurerm/latest/docs"},
+		{name: "guide", link: base + "hashicorp/azurerm/latest/docs/guides/4.0-upgrade-guide"},
+		{name: "missing docs segment", link: base + "hashicorp/azurerm/latest/resources/subnet", error: "want providers/<namespace>/<type>/<version>/docs"},
+		{name: "unknown doc kind", link: base + "hashicorp/azurerm/latest/docs/resource/subnet", error: "want docs/{resources|"},
+		{name: "invalid item name", link: base + "hashicorp/azurerm/latest/docs/resources/Subnet", error: `invalid resources name "Subnet"`},
+		{name: "invalid version", link: base + "hashicorp/azurerm/v4/docs/resources/subnet", error: `invalid version "v4"`},
+		{name: "unknown provider", link: base + "hashicorp/aws/latest/docs/resources/s3_bucket", error: "hashicorp/aws is not in required_providers"},
+		{name: "latest policy", link: base + "hashicorp/azurerm/4.1.0/docs/resources/subnet", policy: RegistryVersionLatest, error: "pins version 4.1.0"},
+		{name: "latest policy accepts latest", link: base + "hashicorp/azurerm/latest/docs/resources/subnet", policy: RegistryVersionLatest},
+		{name: "pinned policy", link: base + "hashicorp/azurerm/latest/docs/resources/subnet", policy: RegistryVersionPinned, error: "requires a pinned version"},
+		{name: "pinned policy accepts version", link: base + "hashicorp/azurerm/4.1.0/docs/resources/subnet", policy: RegistryVersionPinned},
+		{name: "other registry paths are ignored", link: "https://registry.terraform.io/modules/Azure/avm/azurerm/latest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := NewMarkdownContent("See ["+tt.name+"]("+tt.link+").", FormatDocument, nil)
+			errs := NewRegistryLinkValidator(mc, tc, tt.policy).Validate()
+
+			if tt.error == "" {
+				if len(errs) != 0 {
+					t.Errorf("Validate() = %v; want no errors", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tt.error) {
+				t.Errorf("Validate() = %v; want one error containing %q", errs, tt.error)
+			}
+		})
+	}
+}
+
+func TestRegistryLinkValidator_WithoutRequiredProviders(t *testing.T) {
+	tc, _ := NewTerraformContent(t.TempDir())
+	mc := NewMarkdownContent("https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/s3_bucket", FormatDocument, nil)
+
+	if errs := NewRegistryLinkValidator(mc, tc, "").Validate(); len(errs) != 0 {
+		t.Errorf("Validate() = %v; want provider matching skipped when nothing is required", errs)
+	}
+}
+
+func TestNewReadmeValidator_InvalidRegistryVersionPolicy(t *testing.T) {
+	tmpDir := t.TempDir()
+	readmePath := filepath.Join(tmpDir, "README.md")
+	os.WriteFile(readmePath, []byte("# Test"), 0o644)
+
+	_, err := NewReadmeValidator(
+		WithRelativeReadmePath(readmePath),
+		WithRegistryVersionPolicy("newest"),
+	)
+	if err == nil {
+		t.Error("NewReadmeValidator() expected error for unknown registry version policy")
+	}
+}
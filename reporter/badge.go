@@ -0,0 +1,127 @@
+package reporter
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/dkooll/markparsr"
+)
+
+const (
+	badgeLabel       = "docs coverage"
+	badgePassColor   = "#4c1"
+	badgeFailColor   = "#e05d44"
+	badgeUnknownText = "unknown"
+	badgeUnknown     = "#9f9f9f"
+)
+
+// BadgeReporter renders a shields-style SVG badge with the documentation
+// coverage of one module or of all results combined. The badge is green when
+// every module passed and coverage reaches the minimum, red otherwise, and grey
+// when no coverage could be computed. It is rendered locally, without a badge
+// service.
+type BadgeReporter struct {
+	minCoverage float64
+}
+
+func NewBadgeReporter(minCoverage float64) *BadgeReporter {
+	return &BadgeReporter{minCoverage: minCoverage}
+}
+
+func (br *BadgeReporter) Report(w io.Writer, results []*markparsr.Result) error {
+	_, err := io.WriteString(w, br.render(results))
+	return err
+}
+
+// Update writes the badge to path, replacing an existing badge in place. It
+// leaves the file untouched when the badge did not change and reports whether
+// it was written.
+func (br *BadgeReporter) Update(path string, results ...*markparsr.Result) (bool, error) {
+	badge := []byte(br.render(results))
+
+	if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, badge) {
+		return false, nil
+	}
+
+	if err := WriteFile(path, br, results...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (br *BadgeReporter) render(results []*markparsr.Result) string {
+	var total markparsr.CoverageMetric
+	measured := false
+	passed := true
+	for _, result := range results {
+		if !result.Passed() {
+			passed = false
+		}
+		if result.Coverage == nil {
+			continue
+		}
+		measured = true
+		metric := result.Coverage.Documentation()
+		total.Documented += metric.Documented
+		total.Total += metric.Total
+	}
+
+	message, color := badgeUnknownText, badgeUnknown
+	if measured {
+		percent := total.Percent()
+		message = fmt.Sprintf("%d%%", int(math.Floor(percent)))
+		color = badgeFailColor
+		if passed && percent >= br.minCoverage {
+			color = badgePassColor
+		}
+	}
+
+	return badgeSVG(badgeLabel, message, color)
+}
+
+func badgeSVG(label, message, color string) string {
+	labelWidth := badgeTextWidth(label) + 10
+	messageWidth := badgeTextWidth(message) + 10
+	width := labelWidth + messageWidth
+	title := html.EscapeString(label + ": " + message)
+	label, message = html.EscapeString(label), html.EscapeString(message)
+	labelX := float64(labelWidth) / 2
+	messageX := float64(labelWidth) + float64(messageWidth)/2
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s">`+"\n", width, title)
+	fmt.Fprintf(&sb, "<title>%s</title>\n", title)
+	sb.WriteString(`<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>` + "\n")
+	fmt.Fprintf(&sb, `<clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`+"\n", width)
+	fmt.Fprintf(&sb, `<g clip-path="url(#r)"><rect width="%d" height="20" fill="#555"/><rect x="%d" width="%d" height="20" fill="%s"/><rect width="%d" height="20" fill="url(#s)"/></g>`+"\n",
+		labelWidth, labelWidth, messageWidth, color, width)
+	sb.WriteString(`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">` + "\n")
+	fmt.Fprintf(&sb, `<text x="%.1f" y="15" fill="#010101" fill-opacity=".3">%s</text><text x="%.1f" y="14">%s</text>`+"\n", labelX, label, labelX, label)
+	fmt.Fprintf(&sb, `<text x="%.1f" y="15" fill="#010101" fill-opacity=".3">%s</text><text x="%.1f" y="14">%s</text>`+"\n", messageX, message, messageX, message)
+	sb.WriteString("</g>\n</svg>\n")
+	return sb.String()
+}
+
+// badgeTextWidth approximates the width in pixels of s in 11px Verdana, which
+// is close enough to size the badge without font metrics.
+func badgeTextWidth(s string) int {
+	var width float64
+	for _, r := range s {
+		switch {
+		case strings.ContainsRune("ijlt.,:;!|' ", r):
+			width += 3.9
+		case strings.ContainsRune("mwMW%", r):
+			width += 10.5
+		case r >= '0' && r <= '9':
+			width += 7
+		default:
+			width += 6.8
+		}
+	}
+	return int(math.Ceil(width))
+}
@@ -0,0 +1,102 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dkooll/markparsr"
+)
+
+func TestBadgeReporter_Report(t *testing.T) {
+	covered := func(documented, total int) *markparsr.Coverage {
+		return &markparsr.Coverage{Variables: markparsr.CoverageMetric{Documented: documented, Total: total}}
+	}
+	failing := []error{&markparsr.Finding{Severity: markparsr.SeverityError, Message: "broken"}}
+
+	tests := []struct {
+		name        string
+		minCoverage float64
+		results     []*markparsr.Result
+		message     string
+		color       string
+	}{
+		{
+			name:    "full coverage",
+			results: []*markparsr.Result{{Coverage: covered(4, 4)}},
+			message: "100%",
+			color:   badgePassColor,
+		},
+		{
+			name:        "below minimum",
+			minCoverage: 80,
+			results:     []*markparsr.Result{{Coverage: covered(3, 4)}},
+			message:     "75%",
+			color:       badgeFailColor,
+		},
+		{
+			name:    "failed module",
+			results: []*markparsr.Result{{Coverage: covered(4, 4), Errors: failing}},
+			message: "100%",
+			color:   badgeFailColor,
+		},
+		{
+			name:        "tree",
+			minCoverage: 60,
+			results:     []*markparsr.Result{{Coverage: covered(1, 2)}, {Coverage: covered(4, 4)}, {}},
+			message:     "83%",
+			color:       badgePassColor,
+		},
+		{
+			name:    "no coverage",
+			results: []*markparsr.Result{{}},
+			message: badgeUnknownText,
+			color:   badgeUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewBadgeReporter(tt.minCoverage).Report(&buf, tt.results); err != nil {
+				t.Fatalf("Report() error = %v", err)
+			}
+			out := buf.String()
+
+			if err := xml.Unmarshal(buf.Bytes(), new(struct{})); err != nil {
+				t.Fatalf("Report() wrote invalid SVG: %v", err)
+			}
+			if !strings.Contains(out, "<title>docs coverage: "+tt.message+"</title>") {
+				t.Errorf("Report() message is not %q:\n%s", tt.message, out)
+			}
+			if !strings.Contains(out, `fill="`+tt.color+`"`) {
+				t.Errorf("Report() color is not %q:\n%s", tt.color, out)
+			}
+		})
+	}
+}
+
+func TestBadgeReporter_Update(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docs", "coverage.svg")
+	badge := NewBadgeReporter(0)
+	results := sampleResults()
+
+	if changed, err := badge.Update(path, results...); err != nil || !changed {
+		t.Fatalf("Update() = %v, %v; want a new badge", changed, err)
+	}
+	if changed, err := badge.Update(path, results...); err != nil || changed {
+		t.Errorf("Update() = %v, %v; want the unchanged badge left alone", changed, err)
+	}
+
+	results[0].Coverage = nil
+	if changed, err := badge.Update(path, results...); err != nil || !changed {
+		t.Fatalf("Update() = %v, %v; want the badge replaced", changed, err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "docs coverage: unknown") {
+		t.Errorf("Update() did not replace the badge:\n%s", data)
+	}
+}
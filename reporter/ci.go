@@ -0,0 +1,179 @@
+package reporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dkooll/markparsr"
+)
+
+// DetectCIReporter picks the annotation format of the CI system markparsr
+// runs in: GitHub Actions workflow commands or a GitLab Code Quality report.
+func DetectCIReporter() (Reporter, bool) {
+	switch {
+	case os.Getenv("GITHUB_ACTIONS") == "true":
+		return NewGitHubActionsReporter(""), true
+	case os.Getenv("GITLAB_CI") == "true":
+		return NewGitLabCodeQualityReporter(""), true
+	}
+	return nil, false
+}
+
+// GitHubActionsReporter emits ::error, ::warning and ::notice workflow
+// commands so findings appear as inline annotations on the pull request diff.
+// Paths are relative to the base directory, GITHUB_WORKSPACE by default.
+type GitHubActionsReporter struct {
+	baseDir string
+}
+
+func NewGitHubActionsReporter(baseDir string) *GitHubActionsReporter {
+	return &GitHubActionsReporter{baseDir: baseDir}
+}
+
+func (gr *GitHubActionsReporter) Report(w io.Writer, results []*markparsr.Result) error {
+	baseDir, err := resolveBaseDir(gr.baseDir, "GITHUB_WORKSPACE")
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		for _, finding := range result.Findings() {
+			if _, err := fmt.Fprintln(w, githubAnnotation(baseDir, result, finding)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func githubAnnotation(baseDir string, result *markparsr.Result, finding *markparsr.Finding) string {
+	command := "error"
+	switch finding.Severity {
+	case markparsr.SeverityWarning:
+		command = "warning"
+	case markparsr.SeverityInfo:
+		command = "notice"
+	}
+
+	loc := finding.Location
+	if loc.File == "" {
+		loc = markparsr.Location{File: result.ReadmePath}
+	}
+
+	properties := []string{"file=" + escapeGitHubProperty(displayPath(baseDir, loc.File))}
+	if loc.Line > 0 {
+		properties = append(properties, fmt.Sprintf("line=%d", loc.Line))
+	}
+	if loc.Column > 0 {
+		properties = append(properties, fmt.Sprintf("col=%d", loc.Column))
+	}
+	if loc.EndLine > 0 {
+		properties = append(properties, fmt.Sprintf("endLine=%d", loc.EndLine))
+	}
+	if loc.EndColumn > 0 && loc.EndLine == loc.Line {
+		properties = append(properties, fmt.Sprintf("endColumn=%d", loc.EndColumn))
+	}
+	properties = append(properties, "title="+escapeGitHubProperty("markparsr "+finding.Rule))
+
+	return fmt.Sprintf("::%s %s::%s", command, strings.Join(properties, ","), escapeGitHubData(finding.Message))
+}
+
+func escapeGitHubData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeGitHubProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+type codeQualityIssue struct {
+	Description string              `json:"description"`
+	CheckName   string              `json:"check_name"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    codeQualityLocation `json:"location"`
+}
+
+type codeQualityLocation struct {
+	Path  string           `json:"path"`
+	Lines codeQualityLines `json:"lines"`
+}
+
+type codeQualityLines struct {
+	Begin int `json:"begin"`
+}
+
+// GitLabCodeQualityReporter writes a GitLab Code Quality report. Paths are
+// relative to the base directory, CI_PROJECT_DIR by default.
+type GitLabCodeQualityReporter struct {
+	baseDir string
+}
+
+func NewGitLabCodeQualityReporter(baseDir string) *GitLabCodeQualityReporter {
+	return &GitLabCodeQualityReporter{baseDir: baseDir}
+}
+
+func (gr *GitLabCodeQualityReporter) Report(w io.Writer, results []*markparsr.Result) error {
+	baseDir, err := resolveBaseDir(gr.baseDir, "CI_PROJECT_DIR")
+	if err != nil {
+		return err
+	}
+
+	issues := []codeQualityIssue{}
+	occurrences := make(map[string]int)
+	for _, result := range results {
+		for _, finding := range result.Findings() {
+			file := finding.Location.File
+			if file == "" {
+				file = result.ReadmePath
+			}
+			path := displayPath(baseDir, file)
+
+			line := finding.Location.Line
+			if line == 0 {
+				line = 1
+			}
+
+			key := strings.Join([]string{finding.Rule, path, finding.Item}, "|")
+			occurrences[key]++
+
+			issues = append(issues, codeQualityIssue{
+				Description: finding.Message,
+				CheckName:   finding.Rule,
+				Fingerprint: codeQualityFingerprint(key, strconv.Itoa(occurrences[key])),
+				Severity:    codeQualitySeverity(finding.Severity),
+				Location:    codeQualityLocation{Path: path, Lines: codeQualityLines{Begin: line}},
+			})
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(issues)
+}
+
+func codeQualitySeverity(severity markparsr.Severity) string {
+	switch severity {
+	case markparsr.SeverityWarning:
+		return "minor"
+	case markparsr.SeverityInfo:
+		return "info"
+	default:
+		return "major"
+	}
+}
+
+// codeQualityFingerprint leaves out the line and message so GitLab keeps
+// tracking an issue when unrelated edits move it or its wording varies between
+// runs, such as the attempt count of a URL check. Repeated findings for the
+// same item are told apart by their occurrence.
+func codeQualityFingerprint(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "|")))
+	return hex.EncodeToString(sum[:16])
+}
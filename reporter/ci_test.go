@@ -0,0 +1,126 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDetectCIReporter(t *testing.T) {
+	tests := []struct {
+		name   string
+		github string
+		gitlab string
+		want   string
+	}{
+		{name: "github actions", github: "true", want: "*reporter.GitHubActionsReporter"},
+		{name: "gitlab ci", gitlab: "true", want: "*reporter.GitLabCodeQualityReporter"},
+		{name: "github wins", github: "true", gitlab: "true", want: "*reporter.GitHubActionsReporter"},
+		{name: "no ci"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GITHUB_ACTIONS", tt.github)
+			t.Setenv("GITLAB_CI", tt.gitlab)
+
+			reporter, ok := DetectCIReporter()
+			if tt.want == "" {
+				if ok {
+					t.Errorf("DetectCIReporter() = %T; want none", reporter)
+				}
+				return
+			}
+			if got := fmt.Sprintf("%T", reporter); !ok || got != tt.want {
+				t.Errorf("DetectCIReporter() = %s; want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitHubActionsReporter_Report(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewGitHubActionsReporter("/repo").Report(&buf, sampleResults()); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	want := []string{
+		"::warning file=modules/network/README.md,line=12,title=markparsr url::URL permanently redirects: http://old.example.com: use https://new.example.com instead",
+		"::notice file=modules/network/README.md,title=markparsr url::URL unchecked: https://example.com: offline mode and no cached result",
+		"::error file=modules/network/variables.tf,line=3,col=1,endLine=3,endColumn=20,title=markparsr variables::Variables in Terraform but missing in markdown: location",
+	}
+	if got := strings.Split(strings.TrimSpace(buf.String()), "\n"); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Report() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestGitHubAnnotationEscaping(t *testing.T) {
+	if got := escapeGitHubData("100% done\nnext"); got != "100%25 done%0Anext" {
+		t.Errorf("escapeGitHubData() = %q", got)
+	}
+	if got := escapeGitHubProperty("a,b:c"); got != "a%2Cb%3Ac" {
+		t.Errorf("escapeGitHubProperty() = %q", got)
+	}
+}
+
+func TestGitLabCodeQualityReporter_Report(t *testing.T) {
+	t.Setenv("CI_PROJECT_DIR", "/repo")
+
+	var buf bytes.Buffer
+	if err := NewGitLabCodeQualityReporter("").Report(&buf, sampleResults()); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	var issues []codeQualityIssue
+	if err := json.Unmarshal(buf.Bytes(), &issues); err != nil {
+		t.Fatalf("Report() wrote invalid JSON: %v", err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("Report() wrote %d issues; want 3", len(issues))
+	}
+
+	missing := issues[2]
+	if missing.CheckName != "variables" || missing.Severity != "major" || missing.Location.Path != "modules/network/variables.tf" || missing.Location.Lines.Begin != 3 {
+		t.Errorf("issue = %+v", missing)
+	}
+	if issues[0].Severity != "minor" || issues[1].Severity != "info" || issues[1].Location.Lines.Begin != 1 {
+		t.Errorf("issues = %+v", issues[:2])
+	}
+
+	seen := make(map[string]bool)
+	for _, issue := range issues {
+		if len(issue.Fingerprint) != 32 || seen[issue.Fingerprint] {
+			t.Errorf("fingerprint %q is not unique", issue.Fingerprint)
+		}
+		seen[issue.Fingerprint] = true
+	}
+
+	moved := sampleResults()
+	moved[0].Validators[2].Findings[0].Location.Line = 40
+	moved[0].Validators[2].Findings[0].Message += " (reworded)"
+	buf.Reset()
+	NewGitLabCodeQualityReporter("").Report(&buf, moved)
+	var movedIssues []codeQualityIssue
+	json.Unmarshal(buf.Bytes(), &movedIssues)
+	if movedIssues[2].Fingerprint != missing.Fingerprint {
+		t.Error("fingerprint changed when the finding moved or its message changed")
+	}
+
+	duplicated := sampleResults()
+	duplicated[0].Validators[2].Findings = append(duplicated[0].Validators[2].Findings, duplicated[0].Validators[2].Findings[0])
+	buf.Reset()
+	NewGitLabCodeQualityReporter("").Report(&buf, duplicated)
+	var duplicatedIssues []codeQualityIssue
+	json.Unmarshal(buf.Bytes(), &duplicatedIssues)
+	if len(duplicatedIssues) != 4 || duplicatedIssues[2].Fingerprint != missing.Fingerprint || duplicatedIssues[3].Fingerprint == missing.Fingerprint {
+		t.Errorf("repeated findings share a fingerprint: %+v", duplicatedIssues)
+	}
+
+	buf.Reset()
+	NewGitLabCodeQualityReporter("").Report(&buf, nil)
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("empty report = %q; want []", buf.String())
+	}
+}
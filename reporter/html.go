@@ -0,0 +1,144 @@
+package reporter
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dkooll/markparsr"
+)
+
+//go:embed templates/report.html
+var htmlTemplateSource string
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{"plural": plural}).Parse(htmlTemplateSource))
+
+type htmlReport struct {
+	Totals   htmlTotals
+	Modules  []htmlModule
+	Findings []htmlFinding
+	Rules    []string
+}
+
+type htmlTotals struct {
+	Modules  int
+	Failed   int
+	Errors   int
+	Warnings int
+	Notices  int
+}
+
+type htmlModule struct {
+	Path       string
+	ReadmeLink string
+	Passed     bool
+	Coverage   string
+	Breakdown  string
+	Errors     int
+	Warnings   int
+	Notices    int
+}
+
+type htmlFinding struct {
+	Severity string
+	Rule     string
+	Module   string
+	Location string
+	Link     string
+	Message  string
+}
+
+// HTMLReporter writes a single self-contained HTML page, with inline CSS and
+// JavaScript, that lists every module and lets readers filter findings by
+// severity, rule and module, with the documentation coverage of each module
+// in its status row. Paths are relative to the base directory. When
+// sourceURL is set, such as https://github.com/org/repo/blob/main, locations
+// link to the file and line in the hosted repository; otherwise they link to
+// the relative path, which works when the report sits in the base directory.
+type HTMLReporter struct {
+	baseDir   string
+	sourceURL string
+}
+
+func NewHTMLReporter(baseDir, sourceURL string) *HTMLReporter {
+	return &HTMLReporter{baseDir: baseDir, sourceURL: strings.TrimSuffix(sourceURL, "/")}
+}
+
+func (hr *HTMLReporter) Report(w io.Writer, results []*markparsr.Result) error {
+	baseDir, err := resolveBaseDir(hr.baseDir, "GITHUB_WORKSPACE")
+	if err != nil {
+		return err
+	}
+
+	report := htmlReport{}
+	rules := make(map[string]bool)
+
+	for _, result := range results {
+		modulePath := displayPath(baseDir, result.ModulePath)
+		module := htmlModule{
+			Path:     modulePath,
+			Passed:   result.Passed(),
+			Coverage: coverageText(result),
+			Errors:   len(result.Errors),
+			Warnings: len(result.Warnings),
+			Notices:  len(result.Notices),
+		}
+		if c := result.Coverage; c != nil {
+			module.Breakdown = fmt.Sprintf("variables %s, outputs %s, resources %s, data sources %s, variable descriptions %s, output descriptions %s",
+				percentText(c.Variables), percentText(c.Outputs), percentText(c.Resources), percentText(c.DataSources),
+				percentText(c.VariableDescriptions), percentText(c.OutputDescriptions))
+		}
+		if result.ReadmePath != "" {
+			module.ReadmeLink = hr.link(baseDir, result.ReadmePath, 0)
+		}
+		report.Modules = append(report.Modules, module)
+
+		report.Totals.Modules++
+		if !module.Passed {
+			report.Totals.Failed++
+		}
+		report.Totals.Errors += module.Errors
+		report.Totals.Warnings += module.Warnings
+		report.Totals.Notices += module.Notices
+
+		for _, finding := range result.Findings() {
+			rules[finding.Rule] = true
+
+			hf := htmlFinding{
+				Severity: string(finding.Severity),
+				Rule:     finding.Rule,
+				Module:   modulePath,
+				Message:  finding.Message,
+			}
+			if loc := finding.Location; loc.File != "" {
+				hf.Location = displayPath(baseDir, loc.File)
+				if loc.Line > 0 {
+					hf.Location += fmt.Sprintf(":%d", loc.Line)
+				}
+				hf.Link = hr.link(baseDir, loc.File, loc.Line)
+			}
+			report.Findings = append(report.Findings, hf)
+		}
+	}
+
+	report.Rules = slices.Sorted(maps.Keys(rules))
+
+	return htmlTemplate.Execute(w, report)
+}
+
+func (hr *HTMLReporter) link(baseDir, file string, line int) string {
+	path := displayPath(baseDir, file)
+
+	link := path
+	if hr.sourceURL != "" {
+		link = hr.sourceURL + "/" + path
+	}
+	if line > 0 {
+		link += fmt.Sprintf("#L%d", line)
+	}
+	return link
+}
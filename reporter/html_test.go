@@ -0,0 +1,76 @@
+package reporter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dkooll/markparsr"
+)
+
+func TestHTMLReporter_Report(t *testing.T) {
+	tests := []struct {
+		name      string
+		sourceURL string
+		want      []string
+	}{
+		{
+			name: "relative links",
+			want: []string{
+				`<a href="modules/network/README.md"><code>modules/network</code></a>`,
+				`<span class="badge fail">fail</span>`,
+				`<span class="badge pass">pass</span>`,
+				`<td class="num" title="variables 50.0%, outputs 100.0%, resources 100.0%, data sources 100.0%, variable descriptions 100.0%, output descriptions 0.0%">80.0%</td>`,
+				`2 modules, 1 failed, 1 error, 1 warning, 1 notice`,
+				`<tr data-severity="error" data-rule="variables" data-module="modules/network">`,
+				`<a href="modules/network/variables.tf#L3"><code>modules/network/variables.tf:3</code></a>`,
+				`<a href="modules/network/README.md#L12"><code>modules/network/README.md:12</code></a>`,
+				`<option value="url">url</option>`,
+				`<option value="variables">variables</option>`,
+			},
+		},
+		{
+			name:      "source url",
+			sourceURL: "https://github.com/org/repo/blob/main/",
+			want: []string{
+				`<a href="https://github.com/org/repo/blob/main/modules/network/variables.tf#L3">`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewHTMLReporter("/repo", tt.sourceURL).Report(&buf, sampleResults()); err != nil {
+				t.Fatalf("Report() error = %v", err)
+			}
+			out := buf.String()
+
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("Report() is missing %q", want)
+				}
+			}
+			if strings.Contains(out, "<link") || strings.Contains(out, "<script src") {
+				t.Errorf("Report() references external assets")
+			}
+		})
+	}
+}
+
+func TestHTMLReporter_EscapesFindings(t *testing.T) {
+	finding := &markparsr.Finding{Rule: "url", Severity: markparsr.SeverityError, Message: `URL <script>alert(1)</script> is broken`}
+	results := []*markparsr.Result{{
+		ModulePath: "/repo/modules/a",
+		Validators: []markparsr.ValidatorRun{{Name: "URLValidator", Rule: "url", Findings: []*markparsr.Finding{finding}}},
+		Errors:     []error{finding},
+	}}
+
+	var buf bytes.Buffer
+	if err := NewHTMLReporter("/repo", "").Report(&buf, results); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if out := buf.String(); strings.Contains(out, "<script>alert") || !strings.Contains(out, "&lt;script&gt;alert(1)&lt;/script&gt;") {
+		t.Errorf("Report() did not escape the finding message:\n%s", out)
+	}
+}
@@ -0,0 +1,181 @@
+package reporter
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+
+	"github.com/dkooll/markparsr"
+)
+
+const JSONSchemaVersion = 1
+
+type JSONReport struct {
+	SchemaVersion int          `json:"schema_version"`
+	Modules       []JSONModule `json:"modules"`
+	Summary       JSONSummary  `json:"summary"`
+}
+
+type JSONModule struct {
+	ModulePath string          `json:"module_path"`
+	ReadmePath string          `json:"readme_path"`
+	Format     string          `json:"format"`
+	Passed     bool            `json:"passed"`
+	Coverage   *JSONCoverage   `json:"coverage,omitempty"`
+	Validators []JSONValidator `json:"validators"`
+	Suppressed int             `json:"suppressed"`
+	Baselined  int             `json:"baselined"`
+	Findings   []JSONFinding   `json:"findings"`
+}
+
+type JSONCoverage struct {
+	Documentation        JSONCoverageMetric `json:"documentation"`
+	Variables            JSONCoverageMetric `json:"variables"`
+	Outputs              JSONCoverageMetric `json:"outputs"`
+	Resources            JSONCoverageMetric `json:"resources"`
+	DataSources          JSONCoverageMetric `json:"data_sources"`
+	VariableDescriptions JSONCoverageMetric `json:"variable_descriptions"`
+	OutputDescriptions   JSONCoverageMetric `json:"output_descriptions"`
+}
+
+type JSONCoverageMetric struct {
+	Documented int     `json:"documented"`
+	Total      int     `json:"total"`
+	Percent    float64 `json:"percent"`
+}
+
+type JSONValidator struct {
+	Name       string  `json:"name"`
+	Rule       string  `json:"rule"`
+	DurationMS float64 `json:"duration_ms"`
+	Skipped    bool    `json:"skipped"`
+	Findings   int     `json:"findings"`
+}
+
+type JSONFinding struct {
+	Rule       string        `json:"rule"`
+	Severity   string        `json:"severity"`
+	Validator  string        `json:"validator"`
+	Message    string        `json:"message"`
+	Item       string        `json:"item,omitempty"`
+	Suggestion string        `json:"suggestion,omitempty"`
+	Location   *JSONLocation `json:"location,omitempty"`
+}
+
+type JSONLocation struct {
+	File      string `json:"file"`
+	Line      int    `json:"line,omitempty"`
+	Column    int    `json:"column,omitempty"`
+	EndLine   int    `json:"end_line,omitempty"`
+	EndColumn int    `json:"end_column,omitempty"`
+}
+
+type JSONSummary struct {
+	Modules       int `json:"modules"`
+	FailedModules int `json:"failed_modules"`
+	Errors        int `json:"errors"`
+	Warnings      int `json:"warnings"`
+	Notices       int `json:"notices"`
+	Suppressed    int `json:"suppressed"`
+	Baselined     int `json:"baselined"`
+}
+
+type JSONReporter struct{}
+
+func NewJSONReporter() *JSONReporter {
+	return &JSONReporter{}
+}
+
+func (jr *JSONReporter) Report(w io.Writer, results []*markparsr.Result) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(NewJSONReport(results))
+}
+
+func NewJSONReport(results []*markparsr.Result) *JSONReport {
+	report := &JSONReport{
+		SchemaVersion: JSONSchemaVersion,
+		Modules:       make([]JSONModule, 0, len(results)),
+	}
+
+	for _, result := range results {
+		module := JSONModule{
+			ModulePath: result.ModulePath,
+			ReadmePath: result.ReadmePath,
+			Format:     string(result.Format),
+			Passed:     result.Passed(),
+			Validators: make([]JSONValidator, 0, len(result.Validators)),
+			Suppressed: len(result.Suppressed),
+			Baselined:  len(result.Baselined),
+			Findings:   []JSONFinding{},
+		}
+		if c := result.Coverage; c != nil {
+			module.Coverage = &JSONCoverage{
+				Documentation:        newJSONCoverageMetric(c.Documentation()),
+				Variables:            newJSONCoverageMetric(c.Variables),
+				Outputs:              newJSONCoverageMetric(c.Outputs),
+				Resources:            newJSONCoverageMetric(c.Resources),
+				DataSources:          newJSONCoverageMetric(c.DataSources),
+				VariableDescriptions: newJSONCoverageMetric(c.VariableDescriptions),
+				OutputDescriptions:   newJSONCoverageMetric(c.OutputDescriptions),
+			}
+		}
+
+		for _, run := range result.Validators {
+			module.Validators = append(module.Validators, JSONValidator{
+				Name:       run.Name,
+				Rule:       run.Rule,
+				DurationMS: float64(run.Duration.Microseconds()) / 1000,
+				Skipped:    run.Skipped,
+				Findings:   len(run.Findings),
+			})
+			for _, finding := range run.Findings {
+				module.Findings = append(module.Findings, newJSONFinding(run.Name, finding))
+			}
+		}
+
+		report.Modules = append(report.Modules, module)
+		report.Summary.Modules++
+		if !module.Passed {
+			report.Summary.FailedModules++
+		}
+		report.Summary.Errors += len(result.Errors)
+		report.Summary.Warnings += len(result.Warnings)
+		report.Summary.Notices += len(result.Notices)
+		report.Summary.Suppressed += module.Suppressed
+		report.Summary.Baselined += module.Baselined
+	}
+
+	return report
+}
+
+func newJSONCoverageMetric(metric markparsr.CoverageMetric) JSONCoverageMetric {
+	return JSONCoverageMetric{
+		Documented: metric.Documented,
+		Total:      metric.Total,
+		Percent:    math.Round(metric.Percent()*10) / 10,
+	}
+}
+
+func newJSONFinding(validator string, finding *markparsr.Finding) JSONFinding {
+	jf := JSONFinding{
+		Rule:       finding.Rule,
+		Severity:   string(finding.Severity),
+		Validator:  validator,
+		Message:    finding.Message,
+		Item:       finding.Item,
+		Suggestion: finding.Suggestion,
+	}
+
+	if loc := finding.Location; loc.File != "" {
+		jf.Location = &JSONLocation{
+			File:      loc.File,
+			Line:      loc.Line,
+			Column:    loc.Column,
+			EndLine:   loc.EndLine,
+			EndColumn: loc.EndColumn,
+		}
+	}
+
+	return jf
+}
@@ -0,0 +1,80 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJSONReporter_Report(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewJSONReporter().Report(&buf, sampleResults()); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	var report JSONReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("Report() wrote invalid JSON: %v", err)
+	}
+
+	if report.SchemaVersion != JSONSchemaVersion || len(report.Modules) != 2 {
+		t.Fatalf("report = version %d with %d modules", report.SchemaVersion, len(report.Modules))
+	}
+
+	want := JSONSummary{Modules: 2, FailedModules: 1, Errors: 1, Warnings: 1, Notices: 1}
+	if report.Summary != want {
+		t.Errorf("Summary = %+v; want %+v", report.Summary, want)
+	}
+
+	network := report.Modules[0]
+	if network.ModulePath != "/repo/modules/network" || network.ReadmePath != "/repo/modules/network/README.md" || network.Format != "document" || network.Passed {
+		t.Errorf("module = %+v", network)
+	}
+	if len(network.Validators) != 4 || network.Validators[1].DurationMS != 1500 || network.Validators[1].Findings != 2 || !network.Validators[3].Skipped {
+		t.Errorf("validators = %+v", network.Validators)
+	}
+	if len(network.Findings) != 3 {
+		t.Fatalf("findings = %+v; want 3", network.Findings)
+	}
+
+	missing := network.Findings[2]
+	if missing.Rule != "variables" || missing.Severity != "error" || missing.Validator != "ItemValidator" || missing.Item != "location" {
+		t.Errorf("finding = %+v", missing)
+	}
+	if missing.Location == nil || missing.Location.File != "/repo/modules/network/variables.tf" || missing.Location.Line != 3 || missing.Location.EndColumn != 20 {
+		t.Errorf("location = %+v", missing.Location)
+	}
+	if network.Findings[0].Suggestion != "https://new.example.com" || network.Findings[1].Location != nil {
+		t.Errorf("url findings = %+v", network.Findings[:2])
+	}
+
+	if c := network.Coverage; c == nil || c.Documentation != (JSONCoverageMetric{Documented: 4, Total: 5, Percent: 80}) || c.OutputDescriptions.Percent != 0 || c.DataSources.Percent != 100 {
+		t.Errorf("coverage = %+v", network.Coverage)
+	}
+
+	if storage := report.Modules[1]; !storage.Passed || storage.Findings == nil || storage.Coverage != nil {
+		t.Errorf("passing module = %+v; want passed with an empty findings list and no coverage", storage)
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reports", "markparsr.json")
+
+	if err := WriteFile(path, NewJSONReporter(), sampleResults()...); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("report not written: %v", err)
+	}
+	if !json.Valid(data) {
+		t.Error("written report is not valid JSON")
+	}
+
+	if err := NewJSONReporter().Report(failingWriter{}, sampleResults()); err == nil {
+		t.Error("Report() error = nil; want write failure")
+	}
+}
@@ -0,0 +1,159 @@
+package reporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dkooll/markparsr"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	TestCases  []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string         `xml:"name,attr"`
+	ClassName string         `xml:"classname,attr"`
+	Time      string         `xml:"time,attr"`
+	Skipped   *junitSkipped  `xml:"skipped,omitempty"`
+	Failures  []junitFailure `xml:"failure,omitempty"`
+	SystemOut string         `xml:"system-out,omitempty"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// JUnitReporter writes one testsuite per module and one testcase per
+// validator, with coverage metrics as suite properties. Each error finding
+// becomes a failure; warnings and notices are listed in the testcase output so
+// they show up without failing the build.
+type JUnitReporter struct{}
+
+func NewJUnitReporter() *JUnitReporter {
+	return &JUnitReporter{}
+}
+
+func (jr *JUnitReporter) Report(w io.Writer, results []*markparsr.Result) error {
+	suites := junitTestSuites{Name: "markparsr"}
+
+	var total time.Duration
+	for _, result := range results {
+		suite := newJUnitTestSuite(result)
+		suites.Suites = append(suites.Suites, suite.junitTestSuite)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+		total += suite.duration
+	}
+	suites.Time = junitSeconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+type junitSuiteResult struct {
+	junitTestSuite
+	duration time.Duration
+}
+
+func newJUnitTestSuite(result *markparsr.Result) junitSuiteResult {
+	suite := junitSuiteResult{junitTestSuite: junitTestSuite{Name: result.ModulePath}}
+
+	if c := result.Coverage; c != nil {
+		suite.Properties = []junitProperty{
+			{Name: "coverage.documentation", Value: percentText(c.Documentation())},
+			{Name: "coverage.variables", Value: percentText(c.Variables)},
+			{Name: "coverage.outputs", Value: percentText(c.Outputs)},
+			{Name: "coverage.resources", Value: percentText(c.Resources)},
+			{Name: "coverage.data_sources", Value: percentText(c.DataSources)},
+			{Name: "coverage.variable_descriptions", Value: percentText(c.VariableDescriptions)},
+			{Name: "coverage.output_descriptions", Value: percentText(c.OutputDescriptions)},
+		}
+	}
+
+	titles := validatorTitles(result.Validators)
+	for i, run := range result.Validators {
+		testCase := junitTestCase{
+			Name:      titles[i],
+			ClassName: result.ModulePath,
+			Time:      junitSeconds(run.Duration),
+		}
+
+		if run.Skipped {
+			message := "validator did not run"
+			if len(run.Findings) > 0 {
+				message = run.Findings[0].Message
+			}
+			testCase.Skipped = &junitSkipped{Message: message}
+			suite.Skipped++
+		} else {
+			var output []string
+			for _, finding := range run.Findings {
+				if finding.Severity == markparsr.SeverityError {
+					testCase.Failures = append(testCase.Failures, junitFailure{
+						Message: finding.Message,
+						Type:    finding.Rule,
+						Text:    finding.Error(),
+					})
+					continue
+				}
+				output = append(output, fmt.Sprintf("%s: %s", finding.Severity, finding.Error()))
+			}
+			testCase.SystemOut = strings.Join(output, "\n")
+			if len(testCase.Failures) > 0 {
+				suite.Failures++
+			}
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Tests++
+		suite.duration += run.Duration
+	}
+
+	suite.Time = junitSeconds(suite.duration)
+	return suite
+}
+
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
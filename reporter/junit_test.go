@@ -0,0 +1,63 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestJUnitReporter_Report(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewJUnitReporter().Report(&buf, sampleResults()); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	if !strings.HasPrefix(buf.String(), "<?xml") {
+		t.Errorf("Report() output does not start with an XML header")
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatalf("Report() wrote invalid XML: %v", err)
+	}
+
+	if suites.Tests != 5 || suites.Failures != 1 || suites.Skipped != 1 || len(suites.Suites) != 2 {
+		t.Fatalf("testsuites = tests %d, failures %d, skipped %d, suites %d", suites.Tests, suites.Failures, suites.Skipped, len(suites.Suites))
+	}
+
+	network := suites.Suites[0]
+	if network.Name != "/repo/modules/network" || network.Tests != 4 || network.Failures != 1 || network.Skipped != 1 || network.Time != "1.505" {
+		t.Errorf("network suite = %+v", network)
+	}
+
+	if len(network.Properties) != 7 || network.Properties[0] != (junitProperty{Name: "coverage.documentation", Value: "80.0%"}) {
+		t.Errorf("network properties = %+v", network.Properties)
+	}
+
+	names := make([]string, 0, len(network.TestCases))
+	for _, testCase := range network.TestCases {
+		names = append(names, testCase.Name)
+	}
+	if got := strings.Join(names, ","); got != "SectionValidator,URLValidator,ItemValidator (variables),ItemValidator (outputs)" {
+		t.Errorf("testcases = %s", got)
+	}
+
+	urls := network.TestCases[1]
+	if len(urls.Failures) != 0 || !strings.Contains(urls.SystemOut, "warning: README.md:12: URL permanently redirects") || !strings.Contains(urls.SystemOut, "info: URL unchecked") {
+		t.Errorf("URL testcase = %+v; want warnings in system-out", urls)
+	}
+
+	variables := network.TestCases[2]
+	if len(variables.Failures) != 1 || variables.Failures[0].Type != "variables" || variables.Failures[0].Text != "variables.tf:3:1: Variables in Terraform but missing in markdown: location" {
+		t.Errorf("variables testcase = %+v", variables)
+	}
+
+	if network.TestCases[3].Skipped == nil {
+		t.Error("skipped validator has no skipped element")
+	}
+
+	if storage := suites.Suites[1]; storage.Failures != 0 || storage.Tests != 1 || len(storage.Properties) != 0 {
+		t.Errorf("storage suite = %+v", storage)
+	}
+}
@@ -0,0 +1,150 @@
+package reporter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dkooll/markparsr"
+)
+
+// markdownInlineFindings is the number of findings a validator group shows
+// before it is folded into a <details> block.
+const markdownInlineFindings = 5
+
+// MarkdownReporter renders a compact summary for pull request comments and
+// job summaries: a pass/fail table per module, findings grouped by validator
+// and totals. Module and file paths are relative to the base directory,
+// GITHUB_WORKSPACE by default.
+type MarkdownReporter struct {
+	baseDir string
+}
+
+func NewMarkdownReporter(baseDir string) *MarkdownReporter {
+	return &MarkdownReporter{baseDir: baseDir}
+}
+
+func (mr *MarkdownReporter) Report(w io.Writer, results []*markparsr.Result) error {
+	baseDir, err := resolveBaseDir(mr.baseDir, "GITHUB_WORKSPACE")
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## markparsr documentation report\n\n")
+	sb.WriteString("| Module | Status | Coverage | Errors | Warnings | Notices |\n")
+	sb.WriteString("| --- | --- | ---: | ---: | ---: | ---: |\n")
+
+	var failed, errorCount, warningCount, noticeCount int
+	for _, result := range results {
+		status := "✅ Pass"
+		if !result.Passed() {
+			status = "❌ Fail"
+			failed++
+		}
+		errorCount += len(result.Errors)
+		warningCount += len(result.Warnings)
+		noticeCount += len(result.Notices)
+
+		fmt.Fprintf(&sb, "| `%s` | %s | %s | %d | %d | %d |\n", escapeMarkdownCell(displayPath(baseDir, result.ModulePath)), status,
+			coverageText(result), len(result.Errors), len(result.Warnings), len(result.Notices))
+	}
+
+	fmt.Fprintf(&sb, "\n**Totals:** %s, %d failed, %s, %s, %s\n",
+		plural(len(results), "module"), failed, plural(errorCount, "error"), plural(warningCount, "warning"), plural(noticeCount, "notice"))
+
+	for _, result := range results {
+		if len(result.Findings()) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n### `%s`\n", displayPath(baseDir, result.ModulePath))
+		writeMarkdownGroups(&sb, baseDir, result)
+	}
+
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+func writeMarkdownGroups(sb *strings.Builder, baseDir string, result *markparsr.Result) {
+	titles := validatorTitles(result.Validators)
+	for i, run := range result.Validators {
+		if len(run.Findings) == 0 {
+			continue
+		}
+		title := titles[i]
+
+		folded := len(run.Findings) > markdownInlineFindings
+		if folded {
+			fmt.Fprintf(sb, "\n<details>\n<summary>%s: %s</summary>\n\n", title, plural(len(run.Findings), "finding"))
+		} else {
+			fmt.Fprintf(sb, "\n**%s**\n\n", title)
+		}
+
+		for _, finding := range run.Findings {
+			sb.WriteString("- ")
+			sb.WriteString(severityIcon(finding.Severity))
+			if loc := finding.Location; loc.File != "" {
+				fmt.Fprintf(sb, " `%s", displayPath(baseDir, loc.File))
+				if loc.Line > 0 {
+					fmt.Fprintf(sb, ":%d", loc.Line)
+				}
+				sb.WriteString("`")
+			}
+			sb.WriteString(" ")
+			sb.WriteString(escapeMarkdownText(finding.Message))
+			sb.WriteString("\n")
+		}
+
+		if folded {
+			sb.WriteString("\n</details>\n")
+		}
+	}
+}
+
+// AppendGitHubStepSummary appends the Markdown summary of results to the file
+// named by GITHUB_STEP_SUMMARY, shown on the workflow run page.
+func AppendGitHubStepSummary(results ...*markparsr.Result) error {
+	path := os.Getenv("GITHUB_STEP_SUMMARY")
+	if path == "" {
+		return errors.New("GITHUB_STEP_SUMMARY is not set")
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open job summary: %w", err)
+	}
+
+	if err := NewMarkdownReporter("").Report(file, results); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write job summary: %w", err)
+	}
+	return file.Close()
+}
+
+func severityIcon(severity markparsr.Severity) string {
+	switch severity {
+	case markparsr.SeverityWarning:
+		return "⚠️"
+	case markparsr.SeverityInfo:
+		return "ℹ️"
+	default:
+		return "❌"
+	}
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+func escapeMarkdownText(s string) string {
+	return strings.NewReplacer("\r", "", "\n", " ", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+func escapeMarkdownCell(s string) string {
+	return strings.ReplaceAll(escapeMarkdownText(s), "|", "\\|")
+}
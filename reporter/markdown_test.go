@@ -0,0 +1,85 @@
+package reporter
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dkooll/markparsr"
+)
+
+func TestMarkdownReporter_Report(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewMarkdownReporter("/repo").Report(&buf, sampleResults()); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"| `modules/network` | ❌ Fail | 80.0% | 1 | 1 | 1 |",
+		"| `modules/storage` | ✅ Pass | – | 0 | 0 | 0 |",
+		"**Totals:** 2 modules, 1 failed, 1 error, 1 warning, 1 notice",
+		"### `modules/network`",
+		"**URLValidator**",
+		"- ⚠️ `modules/network/README.md:12` URL permanently redirects",
+		"- ℹ️ URL unchecked",
+		"**ItemValidator (variables)**",
+		"- ❌ `modules/network/variables.tf:3` Variables in Terraform but missing in markdown: location",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Report() is missing %q in:\n%s", want, out)
+		}
+	}
+
+	if strings.Contains(out, "### `modules/storage`") || strings.Contains(out, "<details>") {
+		t.Errorf("Report() has a section for a clean module or a folded short list:\n%s", out)
+	}
+}
+
+func TestMarkdownReporter_FoldsLongLists(t *testing.T) {
+	var findings []*markparsr.Finding
+	for i := range 7 {
+		findings = append(findings, &markparsr.Finding{Rule: "url", Severity: markparsr.SeverityError, Message: fmt.Sprintf("URL <%d> | broken", i)})
+	}
+	results := []*markparsr.Result{{
+		ModulePath: "/repo/modules/a|b",
+		Validators: []markparsr.ValidatorRun{{Name: "URLValidator", Rule: "url", Findings: findings}},
+		Errors:     []error{findings[0]},
+	}}
+
+	var buf bytes.Buffer
+	NewMarkdownReporter("/repo").Report(&buf, results)
+	out := buf.String()
+
+	if !strings.Contains(out, "<details>\n<summary>URLValidator: 7 findings</summary>\n\n- ❌ URL &lt;0&gt; | broken") || !strings.Contains(out, "</details>") {
+		t.Errorf("Report() did not fold the long list:\n%s", out)
+	}
+	if !strings.Contains(out, "`modules/a\\|b`") {
+		t.Errorf("Report() did not escape the table cell:\n%s", out)
+	}
+}
+
+func TestAppendGitHubStepSummary(t *testing.T) {
+	t.Setenv("GITHUB_WORKSPACE", "/repo")
+
+	t.Setenv("GITHUB_STEP_SUMMARY", "")
+	if err := AppendGitHubStepSummary(sampleResults()...); err == nil {
+		t.Error("AppendGitHubStepSummary() error = nil without GITHUB_STEP_SUMMARY")
+	}
+
+	path := filepath.Join(t.TempDir(), "summary.md")
+	os.WriteFile(path, []byte("# Earlier step\n"), 0o644)
+	t.Setenv("GITHUB_STEP_SUMMARY", path)
+
+	if err := AppendGitHubStepSummary(sampleResults()...); err != nil {
+		t.Fatalf("AppendGitHubStepSummary() error = %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), "# Earlier step\n## markparsr documentation report") {
+		t.Errorf("summary file = %q; want the report appended", data)
+	}
+}
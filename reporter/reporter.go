@@ -0,0 +1,101 @@
+// Package reporter renders markparsr results for dashboards and CI systems.
+// Every reporter accepts the results of one or more modules, so a single run
+// and a run over a whole repository produce the same kind of report.
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dkooll/markparsr"
+)
+
+type Reporter interface {
+	Report(w io.Writer, results []*markparsr.Result) error
+}
+
+func WriteFile(path string, reporter Reporter, results ...*markparsr.Result) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create report directory: %w", err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+
+	if err := reporter.Report(file, results); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write report %s: %w", path, err)
+	}
+
+	return file.Close()
+}
+
+// resolveBaseDir returns the directory report paths are made relative to:
+// baseDir when set, otherwise the directory named by envVar, otherwise the
+// working directory.
+func resolveBaseDir(baseDir, envVar string) (string, error) {
+	if baseDir == "" {
+		baseDir = os.Getenv(envVar)
+	}
+	if baseDir == "" {
+		return os.Getwd()
+	}
+	return filepath.Abs(baseDir)
+}
+
+func relativePath(baseDir, file string) (string, bool) {
+	if !filepath.IsAbs(file) {
+		return file, true
+	}
+	rel, err := filepath.Rel(baseDir, file)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
+
+// displayPath is file relative to baseDir in slash form, or file unchanged
+// when it lies outside baseDir.
+func displayPath(baseDir, file string) string {
+	if rel, ok := relativePath(baseDir, file); ok {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(file)
+}
+
+// validatorTitles names each run after its validator, adding the rule when
+// several runs share a validator type, such as the variable and output
+// ItemValidators.
+func validatorTitles(runs []markparsr.ValidatorRun) []string {
+	counts := make(map[string]int)
+	for _, run := range runs {
+		counts[run.Name]++
+	}
+
+	titles := make([]string, len(runs))
+	for i, run := range runs {
+		titles[i] = run.Name
+		if counts[run.Name] > 1 {
+			titles[i] = fmt.Sprintf("%s (%s)", run.Name, run.Rule)
+		}
+	}
+	return titles
+}
+
+// coverageText is the documentation coverage of result as a percentage, or a
+// dash when it could not be computed.
+func coverageText(result *markparsr.Result) string {
+	if result.Coverage == nil {
+		return "–"
+	}
+	return percentText(result.Coverage.Documentation())
+}
+
+func percentText(metric markparsr.CoverageMetric) string {
+	return fmt.Sprintf("%.1f%%", metric.Percent())
+}
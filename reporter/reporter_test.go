@@ -0,0 +1,86 @@
+package reporter
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dkooll/markparsr"
+)
+
+func sampleResults() []*markparsr.Result {
+	missing := &markparsr.Finding{
+		Rule:     "variables",
+		Severity: markparsr.SeverityError,
+		Location: markparsr.Location{File: "/repo/modules/network/variables.tf", Line: 3, Column: 1, EndLine: 3, EndColumn: 20},
+		Item:     "location",
+		Message:  "Variables in Terraform but missing in markdown: location",
+	}
+	redirect := &markparsr.Finding{
+		Rule:       "url",
+		Severity:   markparsr.SeverityWarning,
+		Location:   markparsr.Location{File: "/repo/modules/network/README.md", Line: 12},
+		Message:    "URL permanently redirects: http://old.example.com: use https://new.example.com instead",
+		Suggestion: "https://new.example.com",
+	}
+	unchecked := &markparsr.Finding{
+		Rule:     "url",
+		Severity: markparsr.SeverityInfo,
+		Message:  "URL unchecked: https://example.com: offline mode and no cached result",
+	}
+
+	return []*markparsr.Result{
+		{
+			ModulePath: "/repo/modules/network",
+			ReadmePath: "/repo/modules/network/README.md",
+			Format:     markparsr.FormatDocument,
+			Coverage: &markparsr.Coverage{
+				Variables:            markparsr.CoverageMetric{Documented: 1, Total: 2},
+				Outputs:              markparsr.CoverageMetric{Documented: 1, Total: 1},
+				Resources:            markparsr.CoverageMetric{Documented: 2, Total: 2},
+				VariableDescriptions: markparsr.CoverageMetric{Documented: 2, Total: 2},
+				OutputDescriptions:   markparsr.CoverageMetric{Documented: 0, Total: 1},
+			},
+			Validators: []markparsr.ValidatorRun{
+				{Name: "SectionValidator", Rule: "readme-sections", Duration: 2 * time.Millisecond},
+				{Name: "URLValidator", Rule: "url", Duration: 1500 * time.Millisecond, Findings: []*markparsr.Finding{redirect, unchecked}},
+				{Name: "ItemValidator", Rule: "variables", Duration: 3 * time.Millisecond, Findings: []*markparsr.Finding{missing}},
+				{Name: "ItemValidator", Rule: "outputs", Skipped: true},
+			},
+			Errors:   []error{missing},
+			Warnings: []error{redirect},
+			Notices:  []error{unchecked},
+		},
+		{
+			ModulePath: "/repo/modules/storage",
+			ReadmePath: "/repo/modules/storage/README.md",
+			Format:     markparsr.FormatDocument,
+			Validators: []markparsr.ValidatorRun{
+				{Name: "SectionValidator", Rule: "readme-sections", Duration: time.Millisecond},
+			},
+		},
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestResolveBaseDir(t *testing.T) {
+	t.Setenv("GITHUB_WORKSPACE", "/repo/modules")
+
+	if baseDir, err := resolveBaseDir("", "GITHUB_WORKSPACE"); err != nil || baseDir != "/repo/modules" {
+		t.Errorf("resolveBaseDir() = %q, %v; want the environment directory", baseDir, err)
+	}
+	if baseDir, _ := resolveBaseDir("/explicit", "GITHUB_WORKSPACE"); baseDir != "/explicit" {
+		t.Errorf("resolveBaseDir() = %q; want the explicit directory", baseDir)
+	}
+
+	wd, _ := os.Getwd()
+	if baseDir, _ := resolveBaseDir("", "MARKPARSR_UNSET_DIR"); baseDir != wd {
+		t.Errorf("resolveBaseDir() = %q; want the working directory", baseDir)
+	}
+}
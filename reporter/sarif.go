@@ -0,0 +1,211 @@
+package reporter
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/dkooll/markparsr"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifBaseID  = "SRCROOT"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool                   `json:"tool"`
+	OriginalURIBaseIDs map[string]sarifArtifactURI `json:"originalUriBaseIds,omitempty"`
+	Results            []sarifResult               `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	FullDescription      sarifMessage       `json:"fullDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactURI `json:"artifactLocation"`
+	Region           *sarifRegion     `json:"region,omitempty"`
+}
+
+type sarifArtifactURI struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// SARIFReporter writes a SARIF 2.1.0 log for code scanning. File locations
+// are made relative to the base directory, which defaults to
+// GITHUB_WORKSPACE or the working directory. Findings without a file are
+// reported on the module README.
+type SARIFReporter struct {
+	baseDir string
+}
+
+func NewSARIFReporter(baseDir string) *SARIFReporter {
+	return &SARIFReporter{baseDir: baseDir}
+}
+
+func (sr *SARIFReporter) Report(w io.Writer, results []*markparsr.Result) error {
+	baseDir, err := resolveBaseDir(sr.baseDir, "GITHUB_WORKSPACE")
+	if err != nil {
+		return err
+	}
+
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "markparsr",
+			InformationURI: "https://github.com/dkooll/markparsr",
+		}},
+		OriginalURIBaseIDs: map[string]sarifArtifactURI{
+			sarifBaseID: {URI: dirURI(baseDir)},
+		},
+		Results: []sarifResult{},
+	}
+
+	ruleIndex := make(map[string]int)
+	for _, rule := range markparsr.Rules() {
+		ruleIndex[rule.ID] = len(run.Tool.Driver.Rules)
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, newSARIFRule(rule))
+	}
+
+	for _, result := range results {
+		for _, finding := range result.Findings() {
+			index, ok := ruleIndex[finding.Rule]
+			if !ok {
+				index = len(run.Tool.Driver.Rules)
+				ruleIndex[finding.Rule] = index
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, newSARIFRule(markparsr.Rule{
+					ID:          finding.Rule,
+					Name:        finding.Rule,
+					Description: "Findings reported by the " + finding.Rule + " validator.",
+					Severity:    markparsr.SeverityError,
+				}))
+			}
+
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    finding.Rule,
+				RuleIndex: index,
+				Level:     sarifLevel(finding.Severity),
+				Message:   sarifMessage{Text: finding.Message},
+				Locations: []sarifLocation{newSARIFLocation(baseDir, result, finding.Location)},
+			})
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}})
+}
+
+func newSARIFRule(rule markparsr.Rule) sarifRule {
+	return sarifRule{
+		ID:                   rule.ID,
+		Name:                 rule.Name,
+		ShortDescription:     sarifMessage{Text: rule.Name},
+		FullDescription:      sarifMessage{Text: rule.Description},
+		DefaultConfiguration: sarifConfiguration{Level: sarifLevel(rule.Severity)},
+	}
+}
+
+func newSARIFLocation(baseDir string, result *markparsr.Result, loc markparsr.Location) sarifLocation {
+	file := loc.File
+	if file == "" {
+		file = result.ReadmePath
+		loc = markparsr.Location{}
+	}
+
+	location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+		ArtifactLocation: artifactURI(baseDir, file),
+	}}
+	if loc.Line > 0 {
+		location.PhysicalLocation.Region = &sarifRegion{
+			StartLine:   loc.Line,
+			StartColumn: loc.Column,
+			EndLine:     loc.EndLine,
+			EndColumn:   loc.EndColumn,
+		}
+	}
+	return location
+}
+
+func sarifLevel(severity markparsr.Severity) string {
+	switch severity {
+	case markparsr.SeverityWarning:
+		return "warning"
+	case markparsr.SeverityInfo:
+		return "note"
+	default:
+		return "error"
+	}
+}
+
+// artifactURI expresses file relative to baseDir when it lies inside it, so
+// code scanning can map results onto repository files.
+func artifactURI(baseDir, file string) sarifArtifactURI {
+	if rel, ok := relativePath(baseDir, file); ok {
+		return sarifArtifactURI{URI: filepath.ToSlash(rel), URIBaseID: sarifBaseID}
+	}
+	return sarifArtifactURI{URI: fileURI(file)}
+}
+
+func fileURI(path string) string {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
+
+func dirURI(path string) string {
+	return strings.TrimSuffix(fileURI(path), "/") + "/"
+}
@@ -0,0 +1,77 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestSARIFReporter_Report(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewSARIFReporter("/repo").Report(&buf, sampleResults()); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("Report() wrote invalid JSON: %v", err)
+	}
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("log = version %q with %d runs", log.Version, len(log.Runs))
+	}
+	run := log.Runs[0]
+
+	if run.OriginalURIBaseIDs[sarifBaseID].URI != "file:///repo/" {
+		t.Errorf("base URI = %q; want file:///repo/", run.OriginalURIBaseIDs[sarifBaseID].URI)
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("results = %d; want 3", len(run.Results))
+	}
+
+	rules := run.Tool.Driver.Rules
+	for _, result := range run.Results {
+		if rules[result.RuleIndex].ID != result.RuleID {
+			t.Errorf("result rule %q points at rule index %d (%q)", result.RuleID, result.RuleIndex, rules[result.RuleIndex].ID)
+		}
+		if rules[result.RuleIndex].FullDescription.Text == "" {
+			t.Errorf("rule %q has no description", result.RuleID)
+		}
+	}
+
+	tests := []struct {
+		level  string
+		uri    string
+		region *sarifRegion
+	}{
+		{level: "warning", uri: "modules/network/README.md", region: &sarifRegion{StartLine: 12}},
+		{level: "note", uri: "modules/network/README.md"},
+		{level: "error", uri: "modules/network/variables.tf", region: &sarifRegion{StartLine: 3, StartColumn: 1, EndLine: 3, EndColumn: 20}},
+	}
+
+	for i, tt := range tests {
+		result := run.Results[i]
+		location := result.Locations[0].PhysicalLocation
+		if result.Level != tt.level || location.ArtifactLocation.URI != tt.uri || location.ArtifactLocation.URIBaseID != sarifBaseID {
+			t.Errorf("result %d = %s at %+v; want %s at %s", i, result.Level, location.ArtifactLocation, tt.level, tt.uri)
+		}
+		if (location.Region == nil) != (tt.region == nil) || (tt.region != nil && *location.Region != *tt.region) {
+			t.Errorf("result %d region = %+v; want %+v", i, location.Region, tt.region)
+		}
+	}
+}
+
+func TestSARIFReporter_FilesOutsideBase(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewSARIFReporter("/elsewhere").Report(&buf, sampleResults()); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	var log sarifLog
+	json.Unmarshal(buf.Bytes(), &log)
+
+	artifact := log.Runs[0].Results[2].Locations[0].PhysicalLocation.ArtifactLocation
+	if artifact.URI != "file:///repo/modules/network/variables.tf" || artifact.URIBaseID != "" {
+		t.Errorf("artifact = %+v; want an absolute file URI", artifact)
+	}
+}
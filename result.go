@@ -0,0 +1,94 @@
+package markparsr
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+type Result struct {
+	ModulePath string
+	ReadmePath string
+	Format     MarkdownFormat
+	Coverage   *Coverage
+	Validators []ValidatorRun
+	Errors     []error
+	Warnings   []error
+	Notices    []error
+	Suppressed []*Finding
+	Baselined  []*Finding
+}
+
+type ValidatorRun struct {
+	Name     string
+	Rule     string
+	Duration time.Duration
+	Skipped  bool
+	Findings []*Finding
+}
+
+// Findings returns every finding of the run in validator order.
+func (r *Result) Findings() []*Finding {
+	var findings []*Finding
+	for _, run := range r.Validators {
+		findings = append(findings, run.Findings...)
+	}
+	return findings
+}
+
+func (r *Result) Passed() bool {
+	return len(r.Errors) == 0
+}
+
+// asFinding returns err as a finding, attributing plain errors and findings
+// without a rule to the validator that produced them.
+func asFinding(err error, rule string) *Finding {
+	var finding *Finding
+	if errors.As(err, &finding) {
+		if finding.Rule == "" {
+			finding.Rule = rule
+		}
+		if finding.Severity == "" {
+			finding.Severity = SeverityError
+		}
+		return finding
+	}
+
+	return &Finding{
+		Rule:     rule,
+		Severity: SeverityError,
+		Message:  err.Error(),
+		Err:      err,
+	}
+}
+
+func validatorRule(validator Validator) string {
+	switch v := validator.(type) {
+	case *SectionValidator:
+		return RuleSections
+	case *FileValidator:
+		return RuleRequiredFiles
+	case *URLValidator:
+		return RuleURL
+	case *RelativeLinkValidator:
+		return RuleRelativeLinks
+	case *RegistryLinkValidator:
+		return RuleRegistryLinks
+	case *TerraformDefinitionValidator:
+		return RuleResources
+	case *ItemValidator:
+		switch v.blockType {
+		case "variable":
+			return RuleVariables
+		case "output":
+			return RuleOutputs
+		}
+		return strings.ToLower(v.itemType)
+	case *TerraformDiagnosticsValidator:
+		return RuleHCLSyntax
+	case *CoverageValidator:
+		return RuleCoverage
+	default:
+		return strings.ToLower(validatorName(validator))
+	}
+}
@@ -0,0 +1,99 @@
+package markparsr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadmeValidator_RunResult(t *testing.T) {
+	rv := &ReadmeValidator{
+		readmePath: "/repo/module/README.md",
+		modulePath: "/repo/module",
+		validators: []Validator{
+			stubValidator{errs: []error{
+				fmt.Errorf("plain error"),
+				&Finding{Rule: "custom", Severity: SeverityWarning, Message: "tagged"},
+			}},
+			NewTerraformDiagnosticsValidator(&TerraformContent{workspace: t.TempDir(), fileReader: &defaultFileReader{}, hclParser: &defaultHCLParser{}, readDir: os.ReadDir}),
+		},
+	}
+
+	result := rv.Run(t.Context())
+	if result.ModulePath != "/repo/module" || result.ReadmePath != "/repo/module/README.md" || result.Format != FormatDocument {
+		t.Errorf("Run() metadata = %q, %q, %q", result.ModulePath, result.ReadmePath, result.Format)
+	}
+	if len(result.Validators) != 2 {
+		t.Fatalf("Run() recorded %d validators; want 2", len(result.Validators))
+	}
+
+	stub := result.Validators[0]
+	if stub.Name != "stubValidator" || stub.Rule != "stubvalidator" || stub.Skipped || stub.Duration <= 0 {
+		t.Errorf("stub run = %+v", stub)
+	}
+	if len(stub.Findings) != 2 {
+		t.Fatalf("stub run has %d findings; want 2", len(stub.Findings))
+	}
+	if f := stub.Findings[0]; f.Rule != "stubvalidator" || f.Severity != SeverityError || f.Message != "plain error" {
+		t.Errorf("plain error finding = %+v", f)
+	}
+	if f := stub.Findings[1]; f.Rule != "custom" || f.Severity != SeverityWarning {
+		t.Errorf("tagged finding = %+v", f)
+	}
+
+	if rule := result.Validators[1].Rule; rule != "hcl-syntax" {
+		t.Errorf("diagnostics rule = %q; want hcl-syntax", rule)
+	}
+	if len(result.Findings()) != 2 || result.Passed() {
+		t.Errorf("Findings() = %d, Passed() = %v; want 2 findings and a failed run", len(result.Findings()), result.Passed())
+	}
+}
+
+func TestReadmeValidator_RunSkipped(t *testing.T) {
+	rv := &ReadmeValidator{validators: []Validator{stubValidator{}}}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	result := rv.Run(ctx)
+	if !result.Validators[0].Skipped {
+		t.Error("Run() with cancelled context did not mark the validator skipped")
+	}
+}
+
+func TestItemValidator_FindingLocations(t *testing.T) {
+	tmpDir := t.TempDir()
+	tfPath := filepath.Join(tmpDir, "variables.tf")
+	os.WriteFile(tfPath, []byte("variable \"name\" {}\n\nvariable \"location\" {}\n"), 0o644)
+
+	mc := NewMarkdownContent("## Required Inputs\n\n### <a name=\"input_name\"></a> name\n\n### <a name=\"input_extra\"></a> extra\n", FormatDocument, nil)
+	mc.path = filepath.Join(tmpDir, "README.md")
+	tc, _ := NewTerraformContent(tmpDir)
+
+	errs := NewItemValidator(mc, tc, "Variables", "variable", []string{"Required Inputs"}, "variables.tf").Validate()
+	if len(errs) != 2 {
+		t.Fatalf("Validate() returned %d errors; want 2: %v", len(errs), errs)
+	}
+
+	tests := []struct {
+		item string
+		file string
+		line int
+	}{
+		{item: "location", file: tfPath, line: 3},
+		{item: "extra", file: mc.path, line: 5},
+	}
+
+	for i, tt := range tests {
+		finding := asFinding(errs[i], "variables")
+		if finding.Item != tt.item || finding.Location.File != tt.file || finding.Location.Line != tt.line {
+			t.Errorf("finding %d = %+v; want %s at %s:%d", i, finding, tt.item, filepath.Base(tt.file), tt.line)
+		}
+		if !strings.Contains(errs[i].Error(), fmt.Sprintf("%s:%d:", filepath.Base(tt.file), tt.line)) {
+			t.Errorf("Error() = %q; want location prefix", errs[i].Error())
+		}
+	}
+}
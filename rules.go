@@ -0,0 +1,52 @@
+package markparsr
+
+const (
+	RuleSections          = "readme-sections"
+	RuleRequiredFiles     = "required-files"
+	RuleURL               = "url"
+	RuleRelativeLinks     = "relative-links"
+	RuleRegistryLinks     = "registry-links"
+	RuleResources         = "resources"
+	RuleVariables         = "variables"
+	RuleOutputs           = "outputs"
+	RuleHCLSyntax         = "hcl-syntax"
+	RuleCoverage          = "coverage"
+	RuleBaselineStale     = "baseline-stale"
+	RuleUnusedSuppression = "unused-suppression"
+)
+
+type Rule struct {
+	ID          string
+	Name        string
+	Description string
+	Severity    Severity
+}
+
+var rules = []Rule{
+	{ID: RuleSections, Name: "ReadmeSections", Description: "The README contains every required terraform-docs section with its expected heading.", Severity: SeverityError},
+	{ID: RuleRequiredFiles, Name: "RequiredFiles", Description: "The README, variables.tf, outputs.tf, terraform.tf and any additional required files exist and are not empty.", Severity: SeverityError},
+	{ID: RuleURL, Name: "URL", Description: "Absolute URLs in the README respond with an accepted status, do not permanently redirect and, when enabled, point at existing fragments.", Severity: SeverityError},
+	{ID: RuleRelativeLinks, Name: "RelativeLinks", Description: "Relative links and images in the README point at existing files, and fragments match a heading or anchor in the target markdown file.", Severity: SeverityError},
+	{ID: RuleRegistryLinks, Name: "RegistryLinks", Description: "registry.terraform.io provider links are well formed, reference a required provider and follow the version policy.", Severity: SeverityError},
+	{ID: RuleResources, Name: "Resources", Description: "Resources and data sources declared in Terraform and listed in the README match.", Severity: SeverityError},
+	{ID: RuleVariables, Name: "Variables", Description: "Variables declared in Terraform and documented in the README inputs sections match.", Severity: SeverityError},
+	{ID: RuleOutputs, Name: "Outputs", Description: "Outputs declared in Terraform and documented in the README outputs section match.", Severity: SeverityError},
+	{ID: RuleHCLSyntax, Name: "HCLSyntax", Description: "Terraform files parse without HCL diagnostics.", Severity: SeverityError},
+	{ID: RuleCoverage, Name: "Coverage", Description: "The share of documented variables, outputs, resources and data sources, and of described variables and outputs, meets the configured minimum.", Severity: SeverityError},
+	{ID: RuleBaselineStale, Name: "BaselineStale", Description: "Every baseline entry still matches a finding; fixed findings should be removed from the baseline.", Severity: SeverityWarning},
+	{ID: RuleUnusedSuppression, Name: "UnusedSuppression", Description: "Every markparsr-disable comment in the README and markparsr:ignore comment in Terraform suppresses at least one finding.", Severity: SeverityWarning},
+}
+
+// Rules returns the metadata of every built-in rule.
+func Rules() []Rule {
+	return append([]Rule(nil), rules...)
+}
+
+func LookupRule(id string) (Rule, bool) {
+	for _, rule := range rules {
+		if rule.ID == id {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
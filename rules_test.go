@@ -0,0 +1,34 @@
+package markparsr
+
+import "testing"
+
+func TestRules(t *testing.T) {
+	markdown := NewMarkdownContent("", FormatDocument, nil)
+	terraform := &TerraformContent{}
+	validators, err := buildDefaultValidators("README.md", t.TempDir(), markdown, terraform, Options{TolerantParsing: true, MinCoverage: 50}, nil)
+	if err != nil {
+		t.Fatalf("buildDefaultValidators() error = %v", err)
+	}
+
+	for _, validator := range validators {
+		rule := validatorRule(validator)
+		info, ok := LookupRule(rule)
+		if !ok {
+			t.Errorf("%s reports rule %q without metadata", validatorName(validator), rule)
+			continue
+		}
+		if info.Name == "" || info.Description == "" || info.Severity == "" {
+			t.Errorf("rule %q metadata is incomplete: %+v", rule, info)
+		}
+	}
+
+	if _, ok := LookupRule("unknown"); ok {
+		t.Error("LookupRule(unknown) = true")
+	}
+
+	list := Rules()
+	list[0].ID = "changed"
+	if Rules()[0].ID == "changed" {
+		t.Error("Rules() exposes the internal rule table")
+	}
+}
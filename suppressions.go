@@ -0,0 +1,254 @@
+package markparsr
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	readmeDirectivePattern = regexp.MustCompile(`<!--\s*markparsr-(disable-next-line|disable|enable)\b(.*?)-->`)
+	hclDirectivePattern    = regexp.MustCompile(`^\s*(?:#|//)\s*markparsr:ignore\b(.*)$`)
+	hclTrailingPattern     = regexp.MustCompile(`(?:#|//)\s*markparsr:ignore\b(.*)$`)
+)
+
+// suppression is one inline directive. README regions cover the lines from
+// start to end, where an end of 0 means the region was never re-enabled. HCL
+// directives cover the findings of a single block: those located anywhere in
+// its range and those naming it as their item.
+type suppression struct {
+	file  string
+	line  int
+	rules []string
+	start int
+	end   int
+	block *TerraformBlock
+	used  bool
+}
+
+func (s *suppression) covers(rule string) bool {
+	return len(s.rules) == 0 || slices.Contains(s.rules, rule)
+}
+
+func (s *suppression) matches(finding *Finding, readmePath string) bool {
+	if !s.covers(finding.Rule) {
+		return false
+	}
+
+	loc := finding.Location
+	if s.block != nil {
+		if loc.File != s.file {
+			return false
+		}
+		within := loc.Line >= s.block.Range.Start.Line && loc.Line <= s.block.Range.End.Line
+		return (loc.Line > 0 && within) || (finding.Item != "" && finding.Item == s.block.Name())
+	}
+
+	if loc.File != "" && loc.File != readmePath {
+		return false
+	}
+	if loc.Line == 0 {
+		// Findings without a README line, such as missing sections, are only
+		// covered by a region that stays disabled to the end of the file.
+		return s.end == 0
+	}
+	return loc.Line >= s.start && (s.end == 0 || loc.Line <= s.end)
+}
+
+// parseReadmeSuppressions reads markparsr-disable, markparsr-enable and
+// markparsr-disable-next-line comments. An enable comment closes every open
+// region disabling one of its rules, or all open regions when it names none.
+// Comments inside fenced code blocks are examples, not directives.
+func parseReadmeSuppressions(path, data string) []*suppression {
+	var suppressions []*suppression
+	var open []*suppression
+	var fence string
+
+	for i, line := range strings.Split(data, "\n") {
+		lineNo := i + 1
+		if marker := fenceMarker(line); marker != "" {
+			switch {
+			case fence == "":
+				fence = marker
+			case marker[0] == fence[0] && len(marker) >= len(fence):
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+		for _, match := range readmeDirectivePattern.FindAllStringSubmatch(line, -1) {
+			rules := strings.Fields(strings.ReplaceAll(match[2], ",", " "))
+
+			switch match[1] {
+			case "disable-next-line":
+				suppressions = append(suppressions, &suppression{file: path, line: lineNo, rules: rules, start: lineNo + 1, end: lineNo + 1})
+			case "disable":
+				region := &suppression{file: path, line: lineNo, rules: rules, start: lineNo}
+				suppressions = append(suppressions, region)
+				open = append(open, region)
+			case "enable":
+				open = slices.DeleteFunc(open, func(region *suppression) bool {
+					if len(rules) > 0 && len(region.rules) > 0 && !slices.ContainsFunc(rules, region.covers) {
+						return false
+					}
+					region.end = lineNo
+					return true
+				})
+			}
+		}
+	}
+
+	return suppressions
+}
+
+// fenceMarker returns the run of backticks or tildes opening line when it
+// starts a fenced code block.
+func fenceMarker(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 || len(trimmed) < 3 || (trimmed[0] != '`' && trimmed[0] != '~') {
+		return ""
+	}
+	n := len(trimmed) - len(strings.TrimLeft(trimmed, trimmed[:1]))
+	if n < 3 {
+		return ""
+	}
+	return trimmed[:n]
+}
+
+// parseHCLSuppressions finds markparsr:ignore comments directly above a block
+// or trailing its header line.
+func parseHCLSuppressions(blocks []*TerraformBlock, readFile func(string) ([]string, bool)) []*suppression {
+	var suppressions []*suppression
+
+	for _, block := range blocks {
+		file := block.DeclRange.Filename
+		lines, ok := readFile(file)
+		header := block.DeclRange.Start.Line
+		if !ok || header < 1 || header > len(lines) {
+			continue
+		}
+
+		add := func(lineNo int, args string) {
+			suppressions = append(suppressions, &suppression{
+				file:  file,
+				line:  lineNo,
+				rules: strings.Fields(strings.ReplaceAll(args, ",", " ")),
+				block: block,
+			})
+		}
+
+		if match := hclTrailingPattern.FindStringSubmatch(lines[header-1]); match != nil {
+			add(header, match[1])
+		}
+		for lineNo := header - 1; lineNo >= 1; lineNo-- {
+			text := strings.TrimSpace(lines[lineNo-1])
+			if !strings.HasPrefix(text, "#") && !strings.HasPrefix(text, "//") {
+				break
+			}
+			if match := hclDirectivePattern.FindStringSubmatch(text); match != nil {
+				add(lineNo, match[1])
+			}
+		}
+	}
+
+	return suppressions
+}
+
+func (rv *ReadmeValidator) loadSuppressions() []*suppression {
+	var suppressions []*suppression
+	if rv.markdown != nil {
+		suppressions = parseReadmeSuppressions(rv.readmePath, rv.markdown.data)
+	}
+	if rv.terraform == nil {
+		return suppressions
+	}
+	return append(suppressions, terraformSuppressions(rv.terraform)...)
+}
+
+// terraformSuppressions reads the markparsr:ignore directives of the
+// variable, output, resource and data blocks of the module.
+func terraformSuppressions(terraform *TerraformContent) []*suppression {
+	module, err := terraform.Module()
+	if err != nil {
+		return nil
+	}
+
+	files := make(map[string][]string)
+	readFile := func(path string) ([]string, bool) {
+		if lines, ok := files[path]; ok {
+			return lines, lines != nil
+		}
+		data, err := terraform.fileReader.ReadFile(path)
+		if err != nil {
+			files[path] = nil
+			return nil, false
+		}
+		files[path] = strings.Split(string(data), "\n")
+		return files[path], true
+	}
+
+	var blocks []*TerraformBlock
+	blocks = append(blocks, module.Variables...)
+	blocks = append(blocks, module.Outputs...)
+	blocks = append(blocks, module.Resources...)
+	blocks = append(blocks, module.DataSources...)
+	return parseHCLSuppressions(blocks, readFile)
+}
+
+// withoutSuppressed drops the blocks whose markparsr:ignore directive covers
+// rule, so coverage does not count what the validators were told to skip.
+func withoutSuppressed(blocks []*TerraformBlock, suppressions []*suppression, rule string) []*TerraformBlock {
+	var kept []*TerraformBlock
+	for _, block := range blocks {
+		if !slices.ContainsFunc(suppressions, func(s *suppression) bool {
+			return s.block == block && s.covers(rule)
+		}) {
+			kept = append(kept, block)
+		}
+	}
+	return kept
+}
+
+func suppressedBy(suppressions []*suppression, finding *Finding, readmePath string) bool {
+	suppressed := false
+	for _, s := range suppressions {
+		if s.matches(finding, readmePath) {
+			s.used = true
+			suppressed = true
+		}
+	}
+	return suppressed
+}
+
+// unusedSuppressions reports directives that did not suppress any finding,
+// including those naming a rule that does not exist.
+func unusedSuppressions(suppressions []*suppression) []error {
+	var findings []error
+	for _, s := range suppressions {
+		if s.used {
+			continue
+		}
+
+		message := "suppression does not match any finding"
+		if len(s.rules) > 0 {
+			message += ": " + strings.Join(s.rules, ", ")
+		}
+		for _, rule := range s.rules {
+			if _, ok := LookupRule(rule); !ok {
+				message = fmt.Sprintf("suppression names unknown rule: %s", rule)
+				break
+			}
+		}
+
+		findings = append(findings, &Finding{
+			Rule:     RuleUnusedSuppression,
+			Severity: SeverityWarning,
+			Location: Location{File: s.file, Line: s.line},
+			Message:  message,
+		})
+	}
+	return findings
+}
@@ -0,0 +1,250 @@
+package markparsr
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseReadmeSuppressions(t *testing.T) {
+	data := strings.Join([]string{
+		"<!-- markparsr-disable url -->",
+		"https://example.com",
+		"<!-- markparsr-enable -->",
+		"<!-- markparsr-disable-next-line relative-links, registry-links -->",
+		"[guide](docs/guide.md)",
+		"<!-- markparsr-disable url relative-links -->",
+		"<!-- markparsr-enable registry-links -->",
+		"<!-- markparsr-enable url -->",
+		"````markdown",
+		"<!-- markparsr-disable-next-line url -->",
+		"```",
+		"<!-- markparsr-disable url -->",
+		"~~~",
+		"````",
+		"<!--markparsr-disable-->",
+	}, "\n")
+
+	got := parseReadmeSuppressions("/m/README.md", data)
+
+	want := []suppression{
+		{line: 1, rules: []string{"url"}, start: 1, end: 3},
+		{line: 4, rules: []string{"relative-links", "registry-links"}, start: 5, end: 5},
+		{line: 6, rules: []string{"url", "relative-links"}, start: 6, end: 8},
+		{line: 15, start: 15},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parseReadmeSuppressions() returned %d suppressions; want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.line != w.line || g.start != w.start || g.end != w.end || strings.Join(g.rules, ",") != strings.Join(w.rules, ",") || g.file != "/m/README.md" {
+			t.Errorf("suppression %d = %+v; want %+v", i, *g, w)
+		}
+	}
+}
+
+func TestSuppression_Matches(t *testing.T) {
+	readme := "/m/README.md"
+	block := &TerraformBlock{Type: "variable", Labels: []string{"internal"}}
+	block.DeclRange.Filename = "/m/variables.tf"
+	block.DeclRange.Start.Line = 7
+	block.Range = block.DeclRange
+	block.Range.End.Line = 10
+
+	tests := []struct {
+		name        string
+		suppression suppression
+		finding     Finding
+		want        bool
+	}{
+		{
+			name:        "line in region",
+			suppression: suppression{rules: []string{"url"}, start: 2, end: 5},
+			finding:     Finding{Rule: "url", Location: Location{File: readme, Line: 4}},
+			want:        true,
+		},
+		{
+			name:        "line after region",
+			suppression: suppression{rules: []string{"url"}, start: 2, end: 5},
+			finding:     Finding{Rule: "url", Location: Location{File: readme, Line: 6}},
+		},
+		{
+			name:        "other rule",
+			suppression: suppression{rules: []string{"url"}, start: 2},
+			finding:     Finding{Rule: "relative-links", Location: Location{File: readme, Line: 4}},
+		},
+		{
+			name:        "no line in open region",
+			suppression: suppression{rules: []string{"readme-sections"}, start: 1},
+			finding:     Finding{Rule: "readme-sections"},
+			want:        true,
+		},
+		{
+			name:        "no line in closed region",
+			suppression: suppression{start: 1, end: 3},
+			finding:     Finding{Rule: "readme-sections"},
+		},
+		{
+			name:        "other file",
+			suppression: suppression{start: 1},
+			finding:     Finding{Rule: "variables", Location: Location{File: "/m/variables.tf", Line: 4}},
+		},
+		{
+			name:        "block header",
+			suppression: suppression{file: "/m/variables.tf", rules: []string{"variables"}, block: block},
+			finding:     Finding{Rule: "variables", Location: Location{File: "/m/variables.tf", Line: 7}},
+			want:        true,
+		},
+		{
+			name:        "block body",
+			suppression: suppression{file: "/m/variables.tf", rules: []string{"hcl-syntax"}, block: block},
+			finding:     Finding{Rule: "hcl-syntax", Location: Location{File: "/m/variables.tf", Line: 9}},
+			want:        true,
+		},
+		{
+			name:        "after block",
+			suppression: suppression{file: "/m/variables.tf", rules: []string{"hcl-syntax"}, block: block},
+			finding:     Finding{Rule: "hcl-syntax", Location: Location{File: "/m/variables.tf", Line: 11}},
+		},
+		{
+			name:        "block item",
+			suppression: suppression{file: "/m/variables.tf", block: block},
+			finding:     Finding{Rule: "variables", Item: "internal", Location: Location{File: "/m/variables.tf", Line: 9}},
+			want:        true,
+		},
+		{
+			name:        "other block",
+			suppression: suppression{file: "/m/variables.tf", block: block},
+			finding:     Finding{Rule: "variables", Item: "name", Location: Location{File: "/m/variables.tf", Line: 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.suppression.matches(&tt.finding, readme); got != tt.want {
+				t.Errorf("matches() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadmeValidator_Suppressions(t *testing.T) {
+	tmpDir := t.TempDir()
+	readmePath := filepath.Join(tmpDir, "README.md")
+
+	os.WriteFile(readmePath, []byte(`<!-- markparsr-disable readme-sections -->
+# Network
+
+<!-- markparsr-disable-next-line relative-links -->
+See the [guide](docs/guide.md).
+
+<!-- markparsr-disable url -->
+Nothing to check here.
+<!-- markparsr-enable url -->
+
+<!-- markparsr-disable-next-line no-such-rule -->
+
+## Required Inputs
+
+### <a name="input_name"></a> name
+
+The name.
+
+## Outputs
+
+### <a name="output_id"></a> id
+
+The id.
+`), 0o644)
+	os.WriteFile(filepath.Join(tmpDir, "variables.tf"), []byte(`variable "name" {
+  type = string
+}
+
+# Not part of the public interface.
+# markparsr:ignore variables
+variable "internal" {
+  type = string
+}
+
+variable "debug" { # markparsr:ignore outputs
+  type = bool
+}
+
+# TODO: drop the markparsr:ignore once documented.
+variable "legacy" {
+  type = string
+}
+`), 0o644)
+	os.WriteFile(filepath.Join(tmpDir, "outputs.tf"), []byte("output \"id\" {\n  value = var.name\n}\n"), 0o644)
+	os.WriteFile(filepath.Join(tmpDir, "terraform.tf"), []byte("terraform {}\n"), 0o644)
+
+	rv, err := NewReadmeValidator(WithRelativeReadmePath(readmePath))
+	if err != nil {
+		t.Fatalf("NewReadmeValidator() error = %v", err)
+	}
+	result := rv.Run(t.Context())
+
+	var errs []string
+	for _, err := range result.Errors {
+		errs = append(errs, err.Error())
+	}
+	if len(errs) != 2 || !strings.Contains(errs[0], "missing in markdown: debug") || !strings.Contains(errs[1], "missing in markdown: legacy") {
+		t.Errorf("Run() errors = %v; want only the debug and legacy variables", errs)
+	}
+
+	suppressedRules := make(map[string]int)
+	for _, finding := range result.Suppressed {
+		suppressedRules[finding.Rule]++
+	}
+	if suppressedRules[RuleSections] == 0 || suppressedRules[RuleRelativeLinks] != 1 || suppressedRules[RuleVariables] != 1 {
+		t.Errorf("Run() suppressed = %v", suppressedRules)
+	}
+
+	var unused []string
+	for _, err := range result.Warnings {
+		finding := err.(*Finding)
+		if finding.Rule == RuleUnusedSuppression {
+			unused = append(unused, finding.Error())
+		}
+	}
+	want := []string{
+		"README.md:7: suppression does not match any finding: url",
+		"README.md:11: suppression names unknown rule: no-such-rule",
+		"variables.tf:11: suppression does not match any finding: outputs",
+	}
+	if strings.Join(unused, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unused suppressions =\n%s\nwant\n%s", strings.Join(unused, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestReadmeValidator_SuppressesUnreachableURL(t *testing.T) {
+	tmpDir := t.TempDir()
+	readmePath := filepath.Join(tmpDir, "README.md")
+
+	os.WriteFile(readmePath, []byte(`# Network
+
+<!-- markparsr-disable-next-line url -->
+See the [wiki](https://wiki.internal.example.com/network).
+`), 0o644)
+
+	transport := stubTransport{errURLs: map[string]error{
+		"https://wiki.internal.example.com/network": errors.New("no such host"),
+	}}
+	rv, err := NewReadmeValidator(WithRelativeReadmePath(readmePath), WithHTTPTransport(transport))
+	if err != nil {
+		t.Fatalf("NewReadmeValidator() error = %v", err)
+	}
+	result := rv.Run(t.Context())
+
+	for _, finding := range result.Findings() {
+		if finding.Rule == RuleURL || finding.Rule == RuleUnusedSuppression {
+			t.Errorf("Run() finding = %v; want the unreachable URL suppressed", finding)
+		}
+	}
+	if len(result.Suppressed) != 1 || result.Suppressed[0].Item != "https://wiki.internal.example.com/network" || result.Suppressed[0].Location.Line != 4 {
+		t.Errorf("Run() suppressed = %v; want the unreachable URL on line 4", result.Suppressed)
+	}
+}
@@ -2,12 +2,17 @@ package markparsr
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 )
 
 type defaultFileReader struct{}
@@ -23,14 +28,146 @@ func (dhp *defaultHCLParser) ParseHCL(content []byte, filename string) (*hcl.Fil
 	return parser.ParseHCL(content, filename)
 }
 
+type TerraformBlock struct {
+	Type       string
+	Labels     []string
+	Attributes hcl.Attributes
+	Blocks     hcl.Blocks
+	DeclRange  hcl.Range
+	Range      hcl.Range
+}
+
+func newTerraformBlock(block *hcl.Block) *TerraformBlock {
+	labels := make([]string, len(block.Labels))
+	for i, label := range block.Labels {
+		labels[i] = strings.TrimSpace(label)
+	}
+
+	tb := &TerraformBlock{
+		Type:       block.Type,
+		Labels:     labels,
+		Attributes: make(hcl.Attributes),
+		DeclRange:  block.DefRange,
+		Range:      block.DefRange,
+	}
+
+	if body, ok := block.Body.(*hclsyntax.Body); ok {
+		tb.Range = hcl.RangeBetween(block.DefRange, body.SrcRange)
+		for name, attr := range body.Attributes {
+			tb.Attributes[name] = attr.AsHCLAttribute()
+		}
+		for _, nested := range body.Blocks {
+			tb.Blocks = append(tb.Blocks, nested.AsHCLBlock())
+		}
+		return tb
+	}
+
+	if attrs, diags := block.Body.JustAttributes(); !diags.HasErrors() {
+		maps.Copy(tb.Attributes, attrs)
+	}
+
+	return tb
+}
+
+func (tb *TerraformBlock) Name() string {
+	return strings.Join(tb.Labels, ".")
+}
+
+func (tb *TerraformBlock) StringAttribute(name string) (string, bool) {
+	attr, ok := tb.Attributes[name]
+	if !ok {
+		return "", false
+	}
+
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || value.IsNull() || !value.IsKnown() || !value.Type().Equals(cty.String) {
+		return "", false
+	}
+
+	return value.AsString(), true
+}
+
+func (tb *TerraformBlock) key() string {
+	if tb.Type == "provider" {
+		if alias, ok := tb.StringAttribute("alias"); ok {
+			return tb.Type + "." + tb.Name() + "." + alias
+		}
+	}
+	return tb.Type + "." + tb.Name()
+}
+
+func (tb *TerraformBlock) keyed() bool {
+	return len(tb.Labels) > 0
+}
+
+// merge applies an override block using Terraform's override semantics:
+// attributes replace their base counterparts and any nested block type present
+// in the override replaces all base blocks of that type.
+func (tb *TerraformBlock) merge(override *TerraformBlock) {
+	maps.Copy(tb.Attributes, override.Attributes)
+
+	overridden := make(map[string]bool)
+	for _, block := range override.Blocks {
+		overridden[nestedBlockType(block)] = true
+	}
+	if len(overridden) == 0 {
+		return
+	}
+
+	var blocks hcl.Blocks
+	for _, block := range tb.Blocks {
+		if !overridden[nestedBlockType(block)] {
+			blocks = append(blocks, block)
+		}
+	}
+	tb.Blocks = append(blocks, override.Blocks...)
+}
+
+func nestedBlockType(block *hcl.Block) string {
+	if block.Type == "dynamic" && len(block.Labels) > 0 {
+		return block.Labels[0]
+	}
+	return block.Type
+}
+
+func blockLabelNames(blockType string) []string {
+	switch blockType {
+	case "resource", "data":
+		return []string{"type", "name"}
+	case "terraform", "locals":
+		return nil
+	default:
+		return []string{"name"}
+	}
+}
+
+func isOverrideFile(name string) bool {
+	base := strings.TrimSuffix(name, ".tf")
+	return base == "override" || strings.HasSuffix(base, "_override")
+}
+
 type TerraformContent struct {
 	workspace  string
 	fileReader FileReader
 	hclParser  HCLParser
 	readDir    func(string) ([]os.DirEntry, error)
+	tolerant   bool
+	moduleOnce sync.Once
+	module     *TerraformModule
+	moduleErr  error
 }
 
 func NewTerraformContent(modulePath string) (*TerraformContent, error) {
+	return newTerraformContent(modulePath, false)
+}
+
+// NewTolerantTerraformContent keeps whatever could be read from invalid files
+// and reports their HCL diagnostics instead of failing on the first error.
+func NewTolerantTerraformContent(modulePath string) (*TerraformContent, error) {
+	return newTerraformContent(modulePath, true)
+}
+
+func newTerraformContent(modulePath string, tolerant bool) (*TerraformContent, error) {
 	if modulePath == "" {
 		githubWorkspace := os.Getenv("GITHUB_WORKSPACE")
 		if githubWorkspace != "" {
@@ -49,177 +186,255 @@ func NewTerraformContent(modulePath string) (*TerraformContent, error) {
 		fileReader: &defaultFileReader{},
 		hclParser:  &defaultHCLParser{},
 		readDir:    os.ReadDir,
+		tolerant:   tolerant,
 	}, nil
 }
 
 func (tc *TerraformContent) parseFile(filePath string) (*hcl.File, error) {
+	file, diags, err := tc.parseFileDiagnostics(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("error parsing HCL in %s: %v", filepath.Base(filePath), diags)
+	}
+
+	return file, nil
+}
+
+func (tc *TerraformContent) parseFileDiagnostics(filePath string) (*hcl.File, hcl.Diagnostics, error) {
 	content, err := tc.fileReader.ReadFile(filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, nil
+			return nil, nil, nil
 		}
-		return nil, fmt.Errorf("error reading file %s: %w", filepath.Base(filePath), err)
+		return nil, nil, fmt.Errorf("error reading file %s: %w", filepath.Base(filePath), err)
 	}
 
 	file, parseDiags := tc.hclParser.ParseHCL(content, filePath)
-	if parseDiags.HasErrors() {
-		return nil, fmt.Errorf("error parsing HCL in %s: %v", filepath.Base(filePath), parseDiags)
-	}
-
-	return file, nil
+	return file, parseDiags, nil
 }
 
 func (tc *TerraformContent) ExtractItems(filePath, blockType string) ([]string, error) {
-	file, err := tc.parseFile(filePath)
+	blocks, _, err := tc.extractBlocksFromPath(filePath, []hcl.BlockHeaderSchema{
+		{Type: blockType, LabelNames: []string{"name"}},
+	})
 	if err != nil {
 		return nil, err
 	}
-	if file == nil {
-		return []string{}, nil
+
+	items := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		items = append(items, block.Labels[0])
 	}
 
-	return tc.extractItemsFromFile(file, filePath, blockType)
+	return items, nil
 }
 
-func (tc *TerraformContent) extractItemsFromFile(file *hcl.File, filePath, blockType string) ([]string, error) {
-	var items []string
-	body := file.Body
-	hclContent, _, diags := body.PartialContent(&hcl.BodySchema{
-		Blocks: []hcl.BlockHeaderSchema{
-			{Type: blockType, LabelNames: []string{"name"}},
-		},
+func (tc *TerraformContent) Module() (*TerraformModule, error) {
+	tc.moduleOnce.Do(func() {
+		tc.module, tc.moduleErr = tc.loadModule()
 	})
+	return tc.module, tc.moduleErr
+}
 
-	if diags.HasErrors() {
-		return nil, fmt.Errorf("error getting content from %s: %v", filepath.Base(filePath), diags)
+func (tc *TerraformContent) loadModule() (*TerraformModule, error) {
+	blocks, diags, err := tc.loadModuleBlocks(moduleSchemas()...)
+	if err != nil {
+		return nil, err
 	}
+	return newTerraformModule(blocks, diags), nil
+}
 
-	if hclContent == nil {
-		return items, nil
+func (tc *TerraformContent) ExtractModuleItems(blockType string) ([]string, error) {
+	blocks, err := tc.ExtractModuleBlocks(blockType)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, block := range hclContent.Blocks {
-		if len(block.Labels) > 0 {
-			itemName := strings.TrimSpace(block.Labels[0])
-			items = append(items, itemName)
-		}
+	items := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		items = append(items, block.Name())
 	}
 
 	return items, nil
 }
 
-func (tc *TerraformContent) ExtractModuleItems(blockType string) ([]string, error) {
-	files, err := tc.readDir(tc.workspace)
+func (tc *TerraformContent) ExtractResourcesAndDataSources() ([]string, []string, error) {
+	module, err := tc.Module()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return []string{}, nil
-		}
-		return nil, fmt.Errorf("error reading directory %s: %w", tc.workspace, err)
+		return nil, nil, err
 	}
 
-	seen := make(map[string]struct{})
-	var items []string
+	var resources []string
+	for _, block := range module.Resources {
+		resources = append(resources, block.Labels[0], block.Name())
+	}
 
-	for _, file := range files {
-		if file.IsDir() || !strings.HasSuffix(file.Name(), ".tf") {
-			continue
+	var dataSources []string
+	for _, block := range module.DataSources {
+		dataSources = append(dataSources, block.Labels[0], block.Name())
+	}
+
+	return resources, dataSources, nil
+}
+
+// ExtractModuleBlocks returns the blocks of blockType across the module. Block
+// types outside the cached module model, such as locals, check or import, are
+// read from the files on each call.
+func (tc *TerraformContent) ExtractModuleBlocks(blockType string) ([]*TerraformBlock, error) {
+	if !slices.Contains(moduleBlockTypes, blockType) {
+		blocks, _, err := tc.loadModuleBlocks(hcl.BlockHeaderSchema{Type: blockType, LabelNames: blockLabelNames(blockType)})
+		return blocks, err
+	}
+
+	module, err := tc.Module()
+	if err != nil {
+		return nil, err
+	}
+	return module.Blocks(blockType), nil
+}
+
+func (tc *TerraformContent) Diagnostics() (hcl.Diagnostics, error) {
+	module, err := tc.Module()
+	if err != nil {
+		return nil, err
+	}
+	return module.Diagnostics, nil
+}
+
+// loadModuleBlocks collects the blocks matching schemas across the module,
+// applying override files last. Unlabeled blocks such as terraform settings
+// are kept in file order so later definitions take precedence. In tolerant
+// mode diagnostics are returned alongside whatever could be read instead of
+// aborting on the first error.
+func (tc *TerraformContent) loadModuleBlocks(schemas ...hcl.BlockHeaderSchema) ([]*TerraformBlock, hcl.Diagnostics, error) {
+	primaryFiles, overrideFiles, err := tc.moduleFiles()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var blocks []*TerraformBlock
+	var diags hcl.Diagnostics
+	declared := make(map[string]*TerraformBlock)
+
+	for _, filePath := range primaryFiles {
+		fileBlocks, fileDiags, err := tc.extractBlocksFromPath(filePath, schemas)
+		if err != nil {
+			return nil, nil, err
 		}
+		diags = append(diags, fileDiags...)
 
-		filePath := filepath.Join(tc.workspace, file.Name())
-		fileItems, err := tc.ExtractItems(filePath, blockType)
+		for _, block := range fileBlocks {
+			if !block.keyed() {
+				blocks = append(blocks, block)
+				continue
+			}
+
+			key := block.key()
+			if _, ok := declared[key]; ok {
+				continue
+			}
+			declared[key] = block
+			blocks = append(blocks, block)
+		}
+	}
+
+	for _, filePath := range overrideFiles {
+		fileBlocks, fileDiags, err := tc.extractBlocksFromPath(filePath, schemas)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
+		diags = append(diags, fileDiags...)
 
-		for _, item := range fileItems {
-			if _, ok := seen[item]; ok {
+		for _, override := range fileBlocks {
+			if !override.keyed() {
+				blocks = append(blocks, override)
 				continue
 			}
-			seen[item] = struct{}{}
-			items = append(items, item)
+
+			base, ok := declared[override.key()]
+			if ok {
+				base.merge(override)
+				continue
+			}
+
+			if !tc.tolerant {
+				return nil, nil, fmt.Errorf("error applying %s: %s %q has no base declaration to override", filepath.Base(filePath), override.Type, override.Name())
+			}
+			declRange := override.DeclRange
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Missing base declaration to override",
+				Detail:   fmt.Sprintf("There is no %s %q declared in a primary configuration file.", override.Type, override.Name()),
+				Subject:  &declRange,
+			})
 		}
 	}
 
-	return items, nil
+	return blocks, diags, nil
 }
 
-func (tc *TerraformContent) ExtractResourcesAndDataSources() ([]string, []string, error) {
-	var resources []string
-	var dataSources []string
-
+func (tc *TerraformContent) moduleFiles() ([]string, []string, error) {
 	files, err := tc.readDir(tc.workspace)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return resources, dataSources, nil
+			return nil, nil, nil
 		}
 		return nil, nil, fmt.Errorf("error reading directory %s: %w", tc.workspace, err)
 	}
 
+	var primaryFiles []string
+	var overrideFiles []string
+
 	for _, file := range files {
 		if file.IsDir() || !strings.HasSuffix(file.Name(), ".tf") {
 			continue
 		}
 
 		filePath := filepath.Join(tc.workspace, file.Name())
-		fileResources, fileDataSources, err := tc.extractFromFilePath(filePath)
-		if err != nil {
-			return nil, nil, err
+		if isOverrideFile(file.Name()) {
+			overrideFiles = append(overrideFiles, filePath)
+		} else {
+			primaryFiles = append(primaryFiles, filePath)
 		}
-
-		resources = append(resources, fileResources...)
-		dataSources = append(dataSources, fileDataSources...)
 	}
 
-	return resources, dataSources, nil
+	slices.Sort(primaryFiles)
+	slices.Sort(overrideFiles)
+
+	return primaryFiles, overrideFiles, nil
 }
 
-func (tc *TerraformContent) extractFromFilePath(filePath string) ([]string, []string, error) {
-	file, err := tc.parseFile(filePath)
+func (tc *TerraformContent) extractBlocksFromPath(filePath string, schemas []hcl.BlockHeaderSchema) ([]*TerraformBlock, hcl.Diagnostics, error) {
+	file, diags, err := tc.parseFileDiagnostics(filePath)
 	if err != nil {
 		return nil, nil, err
 	}
-	if file == nil {
-		return []string{}, []string{}, nil
+	if diags.HasErrors() && !tc.tolerant {
+		return nil, nil, fmt.Errorf("error parsing HCL in %s: %v", filepath.Base(filePath), diags)
+	}
+	if file == nil || file.Body == nil {
+		return nil, diags, nil
 	}
 
-	return tc.extractResourcesFromFile(file, filePath)
-}
-
-func (tc *TerraformContent) extractResourcesFromFile(file *hcl.File, filePath string) ([]string, []string, error) {
-	var resources []string
-	var dataSources []string
-	body := file.Body
-	hclContent, _, diags := body.PartialContent(&hcl.BodySchema{
-		Blocks: []hcl.BlockHeaderSchema{
-			{Type: "resource", LabelNames: []string{"type", "name"}},
-			{Type: "data", LabelNames: []string{"type", "name"}},
-		},
-	})
-
-	if diags.HasErrors() {
-		return nil, nil, fmt.Errorf("error getting content from %s: %v", filepath.Base(filePath), diags)
+	hclContent, _, contentDiags := file.Body.PartialContent(&hcl.BodySchema{Blocks: schemas})
+	if contentDiags.HasErrors() && !tc.tolerant {
+		return nil, nil, fmt.Errorf("error getting content from %s: %v", filepath.Base(filePath), contentDiags)
 	}
+	diags = append(diags, contentDiags...)
 
 	if hclContent == nil {
-		return resources, dataSources, nil
+		return nil, diags, nil
 	}
 
+	blocks := make([]*TerraformBlock, 0, len(hclContent.Blocks))
 	for _, block := range hclContent.Blocks {
-		if len(block.Labels) >= 2 {
-			resourceType := strings.TrimSpace(block.Labels[0])
-			resourceName := strings.TrimSpace(block.Labels[1])
-			fullResourceName := resourceType + "." + resourceName
-
-			switch block.Type {
-			case "resource":
-				resources = append(resources, resourceType)
-				resources = append(resources, fullResourceName)
-			case "data":
-				dataSources = append(dataSources, resourceType)
-				dataSources = append(dataSources, fullResourceName)
-			}
+		if len(block.Labels) < len(blockLabelNames(block.Type)) {
+			continue
 		}
+		blocks = append(blocks, newTerraformBlock(block))
 	}
 
-	return resources, dataSources, nil
+	return blocks, diags, nil
 }
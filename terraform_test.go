@@ -299,6 +299,140 @@ data "azurerm_client_config" "current" {}
 	}
 }
 
+func TestTerraformContent_OverrideFiles(t *testing.T) {
+	writeFiles := func(t *testing.T, files map[string]string) string {
+		t.Helper()
+		dir := t.TempDir()
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+				t.Fatalf("Failed to write %s: %v", name, err)
+			}
+		}
+		return dir
+	}
+
+	t.Run("override blocks are merged instead of counted", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{
+			"variables.tf": `
+variable "name" {
+  description = "original"
+  type        = string
+}
+
+variable "location" {
+  type = string
+}
+`,
+			"override.tf": `
+variable "name" {
+  description = "overridden"
+}
+`,
+			"b_override.tf": `
+variable "name" {
+  default = "example"
+}
+`,
+			"main.tf": `
+resource "azurerm_resource_group" "main" {
+  name = var.name
+
+  timeouts {
+    create = "5m"
+  }
+}
+`,
+			"main_override.tf": `
+resource "azurerm_resource_group" "main" {
+  timeouts {
+    delete = "5m"
+  }
+}
+`,
+		})
+
+		tc, err := NewTerraformContent(dir)
+		if err != nil {
+			t.Fatalf("NewTerraformContent() error = %v", err)
+		}
+
+		items, err := tc.ExtractModuleItems("variable")
+		if err != nil {
+			t.Fatalf("ExtractModuleItems() error = %v", err)
+		}
+		if !slices.Equal(items, []string{"name", "location"}) {
+			t.Errorf("ExtractModuleItems() = %v; want [name location]", items)
+		}
+
+		resources, _, err := tc.ExtractResourcesAndDataSources()
+		if err != nil {
+			t.Fatalf("ExtractResourcesAndDataSources() error = %v", err)
+		}
+		if len(resources) != 2 {
+			t.Errorf("ExtractResourcesAndDataSources() returned %d resources; want 2: %v", len(resources), resources)
+		}
+
+		variables, err := tc.ExtractModuleBlocks("variable")
+		if err != nil {
+			t.Fatalf("ExtractModuleBlocks() error = %v", err)
+		}
+		for _, v := range variables {
+			if v.Name() != "name" {
+				continue
+			}
+			if desc, _ := v.StringAttribute("description"); desc != "overridden" {
+				t.Errorf("description = %q; want %q", desc, "overridden")
+			}
+			if def, _ := v.StringAttribute("default"); def != "example" {
+				t.Errorf("default = %q; want %q", def, "example")
+			}
+			if _, ok := v.Attributes["type"]; !ok {
+				t.Error("type attribute from base declaration was dropped")
+			}
+		}
+
+		blocks, err := tc.ExtractModuleBlocks("resource")
+		if err != nil {
+			t.Fatalf("ExtractModuleBlocks() error = %v", err)
+		}
+		if len(blocks) != 1 || len(blocks[0].Blocks) != 1 {
+			t.Fatalf("expected a single resource with one timeouts block, got %+v", blocks)
+		}
+		attrs, _ := blocks[0].Blocks[0].Body.JustAttributes()
+		if _, ok := attrs["delete"]; !ok {
+			t.Error("nested timeouts block was not replaced by override")
+		}
+	})
+
+	t.Run("override without base declaration", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{
+			"variables.tf": `variable "name" {}`,
+			"override.tf":  `variable "missing" {}`,
+		})
+
+		tc, _ := NewTerraformContent(dir)
+		if _, err := tc.ExtractModuleItems("variable"); err == nil {
+			t.Error("ExtractModuleItems() expected error for override without base declaration")
+		}
+	})
+}
+
+func TestIsOverrideFile(t *testing.T) {
+	tests := map[string]bool{
+		"override.tf":      true,
+		"main_override.tf": true,
+		"main.tf":          false,
+		"overrides.tf":     false,
+		"my-override.tf":   false,
+	}
+
+	for name, want := range tests {
+		if got := isOverrideFile(name); got != want {
+			t.Errorf("isOverrideFile(%q) = %v; want %v", name, got, want)
+		}
+	}
+}
+
 func TestTerraformContent_ParseFile(t *testing.T) {
 	validHCL := `variable "test" { type = string }`
 	invalidHCL := `variable "test" { invalid syntax`
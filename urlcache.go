@@ -0,0 +1,251 @@
+package markparsr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const urlCacheVersion = 1
+
+type URLCacheEntry struct {
+	Status    int           `json:"status"`
+	CheckedAt time.Time     `json:"checked_at"`
+	FinalURL  string        `json:"final_url,omitempty"`
+	Redirects []URLRedirect `json:"redirects,omitempty"`
+	HTML      bool          `json:"html,omitempty"`
+	Anchors   []string      `json:"anchors,omitempty"`
+	Truncated bool          `json:"truncated,omitempty"`
+}
+
+type URLRedirect struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Status int    `json:"status"`
+}
+
+func (r URLRedirect) Permanent() bool {
+	return r.Status == http.StatusMovedPermanently || r.Status == http.StatusPermanentRedirect
+}
+
+type urlCacheFile struct {
+	Version int                      `json:"version"`
+	Entries map[string]URLCacheEntry `json:"entries"`
+}
+
+type urlCheckResult struct {
+	entry    URLCacheEntry
+	attempts int
+	err      error
+}
+
+type urlCheckCall struct {
+	done   chan struct{}
+	result urlCheckResult
+}
+
+// URLCache remembers URL check results for the lifetime of the process and,
+// when backed by a file, across runs. Concurrent checks of the same URL share
+// a single request.
+type URLCache struct {
+	mu       sync.Mutex
+	path     string
+	ttl      time.Duration
+	entries  map[string]URLCacheEntry
+	failures map[string]urlCheckResult
+	inflight map[string]*urlCheckCall
+	dirty    bool
+}
+
+var (
+	sharedURLCachesMu sync.Mutex
+	sharedURLCaches   = make(map[string]*URLCache)
+)
+
+func NewURLCache(path string, ttl time.Duration) (*URLCache, error) {
+	cache := &URLCache{
+		path:     path,
+		ttl:      ttl,
+		entries:  make(map[string]URLCacheEntry),
+		failures: make(map[string]urlCheckResult),
+		inflight: make(map[string]*urlCheckCall),
+	}
+
+	if path == "" {
+		return cache, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cache, nil
+		}
+		return nil, fmt.Errorf("failed to read URL cache %s: %w", path, err)
+	}
+
+	var file urlCacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse URL cache %s: %w", path, err)
+	}
+	if file.Version == urlCacheVersion && file.Entries != nil {
+		cache.entries = file.Entries
+	}
+
+	return cache, nil
+}
+
+// sharedURLCache returns the process-wide cache for path so every module in a
+// multi-module run reads and writes the same entries.
+func sharedURLCache(path string, ttl time.Duration) (*URLCache, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve URL cache path: %w", err)
+	}
+
+	sharedURLCachesMu.Lock()
+	defer sharedURLCachesMu.Unlock()
+
+	if cache, ok := sharedURLCaches[absPath]; ok {
+		return cache, nil
+	}
+
+	cache, err := NewURLCache(absPath, ttl)
+	if err != nil {
+		return nil, err
+	}
+	sharedURLCaches[absPath] = cache
+	return cache, nil
+}
+
+func (c *URLCache) Get(url string) (URLCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.get(url)
+}
+
+func (c *URLCache) get(url string) (URLCacheEntry, bool) {
+	entry, ok := c.entries[url]
+	if !ok {
+		return URLCacheEntry{}, false
+	}
+	if c.ttl > 0 && time.Since(entry.CheckedAt) > c.ttl {
+		return URLCacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (c *URLCache) Put(url string, entry URLCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[url] = entry
+	c.dirty = true
+}
+
+// check returns the cached result for url or runs fn exactly once, even when
+// several validators ask for the same URL concurrently. Transport failures
+// and transient statuses are kept in memory only, and results of checks cut
+// short by a cancelled or expired context are not kept at all, so the next
+// caller with a live context checks the URL again.
+func (c *URLCache) check(ctx context.Context, url string, fn func() urlCheckResult) urlCheckResult {
+	for {
+		c.mu.Lock()
+		if entry, ok := c.get(url); ok {
+			c.mu.Unlock()
+			return urlCheckResult{entry: entry}
+		}
+		if result, ok := c.failures[url]; ok {
+			c.mu.Unlock()
+			return result
+		}
+		if call, ok := c.inflight[url]; ok {
+			c.mu.Unlock()
+			select {
+			case <-call.done:
+				if isContextError(call.result.err) && ctx.Err() == nil {
+					continue
+				}
+				return call.result
+			case <-ctx.Done():
+				return urlCheckResult{err: ctx.Err()}
+			}
+		}
+
+		call := &urlCheckCall{done: make(chan struct{})}
+		c.inflight[url] = call
+		c.mu.Unlock()
+
+		call.result = fn()
+
+		c.mu.Lock()
+		delete(c.inflight, url)
+		switch {
+		case isContextError(call.result.err) || ctx.Err() != nil:
+		case call.result.err != nil || isRetryableStatus(call.result.entry.Status):
+			c.failures[url] = call.result
+		default:
+			c.entries[url] = call.result.entry
+			c.dirty = true
+		}
+		c.mu.Unlock()
+		close(call.done)
+
+		return call.result
+	}
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// Save writes the cache file, dropping entries that have expired so the file
+// does not grow with URLs no longer linked.
+func (c *URLCache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for url := range c.entries {
+		if _, ok := c.get(url); !ok {
+			delete(c.entries, url)
+			c.dirty = true
+		}
+	}
+
+	if c.path == "" || !c.dirty {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(urlCacheFile{Version: urlCacheVersion, Entries: c.entries}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode URL cache: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create URL cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write URL cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write URL cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write URL cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to write URL cache: %w", err)
+	}
+
+	c.dirty = false
+	return nil
+}
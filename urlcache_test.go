@@ -0,0 +1,220 @@
+package markparsr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewURLCache(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	t.Run("missing file starts empty", func(t *testing.T) {
+		cache, err := NewURLCache(filepath.Join(tmpDir, "missing.json"), time.Hour)
+		if err != nil {
+			t.Fatalf("NewURLCache() error = %v", err)
+		}
+		if _, ok := cache.Get("http://example.com"); ok {
+			t.Error("Get() found an entry in an empty cache")
+		}
+	})
+
+	t.Run("corrupt file", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "corrupt.json")
+		if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+			t.Fatalf("Failed to write cache file: %v", err)
+		}
+		if _, err := NewURLCache(path, time.Hour); err == nil {
+			t.Error("NewURLCache() error = nil; want parse error")
+		}
+	})
+
+	t.Run("unknown version is ignored", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "old.json")
+		data := `{"version": 0, "entries": {"http://example.com": {"status": 200}}}`
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatalf("Failed to write cache file: %v", err)
+		}
+		cache, err := NewURLCache(path, 0)
+		if err != nil {
+			t.Fatalf("NewURLCache() error = %v", err)
+		}
+		if _, ok := cache.Get("http://example.com"); ok {
+			t.Error("Get() returned an entry from an unsupported cache version")
+		}
+	})
+}
+
+func TestURLCache_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "urls.json")
+
+	cache, _ := NewURLCache(path, time.Hour)
+	cache.Put("http://example.com/ok", URLCacheEntry{Status: http.StatusOK, CheckedAt: time.Now()})
+	cache.Put("http://example.com/stale", URLCacheEntry{Status: http.StatusOK, CheckedAt: time.Now().Add(-2 * time.Hour)})
+
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := NewURLCache(path, time.Hour)
+	if err != nil {
+		t.Fatalf("NewURLCache() error = %v", err)
+	}
+
+	if entry, ok := loaded.Get("http://example.com/ok"); !ok || entry.Status != http.StatusOK {
+		t.Errorf("Get(ok) = %v, %v; want cached 200", entry, ok)
+	}
+	if _, ok := loaded.Get("http://example.com/stale"); ok {
+		t.Error("Get(stale) returned an entry older than the TTL")
+	}
+	if data, _ := os.ReadFile(path); strings.Contains(string(data), "example.com/stale") {
+		t.Error("Save() kept an expired entry in the cache file")
+	}
+
+	noExpiry, _ := NewURLCache(path, 0)
+	noExpiry.Put("http://example.com/stale", URLCacheEntry{Status: http.StatusOK, CheckedAt: time.Now().Add(-2 * time.Hour)})
+	noExpiry.Save()
+	if reloaded, _ := NewURLCache(path, 0); reloaded.entries["http://example.com/stale"].Status != http.StatusOK {
+		t.Error("Save() with no TTL dropped an old entry; want entries to never expire")
+	}
+}
+
+func TestURLCache_CheckIgnoresContextErrors(t *testing.T) {
+	cache, _ := NewURLCache("", 0)
+	url := "http://example.com/slow"
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	result := cache.check(ctx, url, func() urlCheckResult {
+		return urlCheckResult{err: ctx.Err(), attempts: 1}
+	})
+	if !errors.Is(result.err, context.Canceled) {
+		t.Fatalf("check() error = %v; want context.Canceled", result.err)
+	}
+
+	calls := 0
+	result = cache.check(t.Context(), url, func() urlCheckResult {
+		calls++
+		return urlCheckResult{entry: URLCacheEntry{Status: http.StatusOK}, attempts: 1}
+	})
+	if calls != 1 || result.err != nil || result.entry.Status != http.StatusOK {
+		t.Errorf("check() after a cancelled check = %+v with %d fetches; want a fresh fetch", result, calls)
+	}
+}
+
+func TestURLCache_CheckWaiterHonorsContext(t *testing.T) {
+	cache, _ := NewURLCache("", 0)
+	url := "http://example.com/slow"
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go cache.check(t.Context(), url, func() urlCheckResult {
+		close(started)
+		<-release
+		return urlCheckResult{entry: URLCacheEntry{Status: http.StatusOK}, attempts: 1}
+	})
+	<-started
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+	result := cache.check(ctx, url, func() urlCheckResult {
+		t.Error("waiter ran its own check while the first one was in flight")
+		return urlCheckResult{}
+	})
+	if !errors.Is(result.err, context.DeadlineExceeded) {
+		t.Errorf("check() error = %v; want the waiter's own deadline", result.err)
+	}
+}
+
+func TestURLValidator_CacheChecksEachURLOnce(t *testing.T) {
+	transport := newSequenceTransport(map[string][]stubResponse{
+		"http://example.com/a":       {{status: http.StatusOK}},
+		"http://example.com/missing": {{status: http.StatusNotFound}},
+	})
+
+	content := "http://example.com/a http://example.com/a http://example.com/missing http://example.com/missing"
+	cache, _ := NewURLCache("", 0)
+
+	for range 2 {
+		options := Options{URLPolicy: DefaultURLPolicy(), URLCache: cache, HTTPClient: &http.Client{Transport: transport}}
+		validators, err := buildDefaultValidators("README.md", t.TempDir(), NewMarkdownContent(content, FormatDocument, nil), &TerraformContent{}, options, nil)
+		if err != nil {
+			t.Fatalf("buildDefaultValidators() error = %v", err)
+		}
+
+		errs := validators[2].Validate()
+		if len(errs) != 2 {
+			t.Errorf("Validate() returned %d errors; want 2 for the duplicated missing URL", len(errs))
+		}
+	}
+
+	want := []string{"HEAD http://example.com/a", "HEAD http://example.com/missing", "GET http://example.com/missing"}
+	if len(transport.requests) != len(want) {
+		t.Errorf("made %d requests; want %d: %v", len(transport.requests), len(want), transport.requests)
+	}
+}
+
+func TestURLValidator_CacheFile(t *testing.T) {
+	client := &http.Client{Transport: newSequenceTransport(map[string][]stubResponse{
+		"http://example.com/ok":   {{status: http.StatusOK}},
+		"http://example.com/down": {{status: http.StatusServiceUnavailable}},
+	})}
+
+	path := filepath.Join(t.TempDir(), "urls.json")
+	policy := URLPolicy{CacheFile: path, CacheTTL: time.Hour}
+	content := NewMarkdownContent("http://example.com/ok http://example.com/down", FormatDocument, nil)
+
+	uv, err := NewURLValidatorWithPolicy(content, policy, client, nil)
+	if err != nil {
+		t.Fatalf("NewURLValidatorWithPolicy() error = %v", err)
+	}
+	uv.Validate()
+
+	shared, _ := NewURLValidatorWithPolicy(content, policy, client, nil)
+	if shared.cache != uv.cache {
+		t.Error("validators with the same cache file do not share the in-memory cache")
+	}
+
+	persisted, err := NewURLCache(path, time.Hour)
+	if err != nil {
+		t.Fatalf("NewURLCache() error = %v", err)
+	}
+	if _, ok := persisted.Get("http://example.com/ok"); !ok {
+		t.Error("cache file is missing the successful check")
+	}
+	if _, ok := persisted.Get("http://example.com/down"); ok {
+		t.Error("cache file persisted a transient failure")
+	}
+}
+
+func TestURLValidator_Offline(t *testing.T) {
+	transport := newSequenceTransport(map[string][]stubResponse{})
+
+	cache, _ := NewURLCache("", 0)
+	cache.Put("http://example.com/ok", URLCacheEntry{Status: http.StatusOK, CheckedAt: time.Now()})
+	cache.Put("http://example.com/gone", URLCacheEntry{Status: http.StatusNotFound, CheckedAt: time.Now()})
+
+	content := NewMarkdownContent("http://example.com/ok http://example.com/gone http://example.com/new", FormatDocument, nil)
+	uv, _ := NewURLValidatorWithPolicy(content, URLPolicy{Offline: true}, &http.Client{Transport: transport}, cache)
+
+	errs := uv.Validate()
+	if len(transport.requests) != 0 {
+		t.Errorf("offline mode made requests: %v", transport.requests)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("Validate() returned %d errors; want 2: %v", len(errs), errs)
+	}
+
+	if severityOf(errs[0]) != SeverityError || !strings.Contains(errs[0].Error(), "Status: 404 (cached result)") {
+		t.Errorf("errs[0] = %v; want cached 404 error", errs[0])
+	}
+	if severityOf(errs[1]) != SeverityInfo || !strings.Contains(errs[1].Error(), "URL unchecked: http://example.com/new") {
+		t.Errorf("errs[1] = %v; want unchecked notice", errs[1])
+	}
+}
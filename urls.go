@@ -1,8 +1,17 @@
 package markparsr
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand/v2"
+	"mime"
 	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -10,61 +19,554 @@ import (
 	"mvdan.cc/xurls/v2"
 )
 
-var httpClient = &http.Client{
-	Timeout: 10 * time.Second,
+// URLPolicy controls which README links URLValidator checks and how responses
+// are judged. Patterns anchored with ^ or $ are regular expressions, anything
+// else is a glob. Zero concurrency, timeouts, backoffs, body limit, cache TTL
+// and accepted codes fall back to the values of DefaultURLPolicy; a negative
+// CacheTTL keeps cached results forever.
+type URLPolicy struct {
+	SkipPatterns        []string
+	AllowedHosts        []string
+	DeniedHosts         []string
+	MaxConcurrency      int
+	Timeout             time.Duration
+	AcceptedStatusCodes []int
+	WarningStatusCodes  []int
+	Retries             int
+	RetryBackoff        time.Duration
+	MaxRetryBackoff     time.Duration
+	CheckFragments      bool
+	MaxBodyBytes        int64
+	CacheFile           string
+	CacheTTL            time.Duration
+	Offline             bool
+}
+
+func DefaultURLPolicy() URLPolicy {
+	return URLPolicy{
+		SkipPatterns:        []string{"*registry.terraform.io/providers/*"},
+		MaxConcurrency:      5,
+		Timeout:             10 * time.Second,
+		AcceptedStatusCodes: []int{http.StatusOK},
+		RetryBackoff:        500 * time.Millisecond,
+		MaxRetryBackoff:     30 * time.Second,
+		MaxBodyBytes:        2 << 20,
+		CacheTTL:            24 * time.Hour,
+	}
+}
+
+type compiledURLPolicy struct {
+	URLPolicy
+	skip    []*regexp.Regexp
+	allowed []*regexp.Regexp
+	denied  []*regexp.Regexp
+}
+
+func compileURLPolicy(policy URLPolicy) (*compiledURLPolicy, error) {
+	defaults := DefaultURLPolicy()
+	if policy.MaxConcurrency <= 0 {
+		policy.MaxConcurrency = defaults.MaxConcurrency
+	}
+	if policy.Timeout <= 0 {
+		policy.Timeout = defaults.Timeout
+	}
+	if len(policy.AcceptedStatusCodes) == 0 {
+		policy.AcceptedStatusCodes = defaults.AcceptedStatusCodes
+	}
+	if policy.RetryBackoff <= 0 {
+		policy.RetryBackoff = defaults.RetryBackoff
+	}
+	if policy.MaxRetryBackoff <= 0 {
+		policy.MaxRetryBackoff = defaults.MaxRetryBackoff
+	}
+	if policy.MaxBodyBytes <= 0 {
+		policy.MaxBodyBytes = defaults.MaxBodyBytes
+	}
+	if policy.CacheTTL == 0 {
+		policy.CacheTTL = defaults.CacheTTL
+	}
+
+	compiled := &compiledURLPolicy{URLPolicy: policy}
+
+	var err error
+	if compiled.skip, err = compilePatterns(policy.SkipPatterns); err != nil {
+		return nil, fmt.Errorf("invalid URL skip pattern: %w", err)
+	}
+	if compiled.allowed, err = compilePatterns(policy.AllowedHosts); err != nil {
+		return nil, fmt.Errorf("invalid allowed host: %w", err)
+	}
+	if compiled.denied, err = compilePatterns(policy.DeniedHosts); err != nil {
+		return nil, fmt.Errorf("invalid denied host: %w", err)
+	}
+
+	return compiled, nil
+}
+
+// decide reports whether rawURL should be requested, returning a finding
+// instead when the policy rejects the link outright.
+func (p *compiledURLPolicy) decide(rawURL string) (bool, error) {
+	if matchesAnyPattern(p.skip, rawURL) {
+		return false, nil
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false, newURLFinding(SeverityError, rawURL, "invalid URL: %s: %w", rawURL, err)
+	}
+	host := strings.ToLower(parsed.Hostname())
+
+	if matchesAnyPattern(p.denied, host) {
+		return false, newURLFinding(SeverityError, rawURL, "URL host is denied by policy: %s", rawURL)
+	}
+	if len(p.allowed) > 0 && !matchesAnyPattern(p.allowed, host) {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (p *compiledURLPolicy) accepts(status int) bool {
+	return slices.Contains(p.AcceptedStatusCodes, status)
+}
+
+func (p *compiledURLPolicy) judge(rawURL string, entry URLCacheEntry, attempts int) error {
+	if !p.accepts(entry.Status) {
+		severity := SeverityError
+		if slices.Contains(p.WarningStatusCodes, entry.Status) {
+			severity = SeverityWarning
+		}
+		return newURLFinding(severity, rawURL, "URL returned non-OK status: %s: Status: %d (%s)", rawURL, entry.Status, attemptsText(attempts))
+	}
+
+	if target, ok := permanentTarget(entry.Redirects); ok {
+		// Redirects are followed for the page alone, so carry the fragment
+		// over unless the new location brings its own.
+		if _, fragment, found := strings.Cut(rawURL, "#"); found && !strings.Contains(target, "#") {
+			target += "#" + fragment
+		}
+		finding := newURLFinding(SeverityWarning, rawURL, "URL permanently redirects: %s: use %s instead", rawURL, target)
+		finding.Suggestion = target
+		return finding
+	}
+
+	return nil
+}
+
+// permanentTarget follows the permanent redirects at the start of a chain and
+// returns where they lead. A chain starting with a temporary redirect is left
+// alone since the original URL is still the one to link to.
+func permanentTarget(redirects []URLRedirect) (string, bool) {
+	target := ""
+	for _, redirect := range redirects {
+		if !redirect.Permanent() {
+			break
+		}
+		target = redirect.To
+	}
+	return target, target != ""
+}
+
+// checksFragment skips fragments used as client-side routes or text
+// directives, which never correspond to an element on the page.
+func (p *compiledURLPolicy) checksFragment(fragment string) bool {
+	if !p.CheckFragments || fragment == "" {
+		return false
+	}
+	return !strings.HasPrefix(fragment, "!") && !strings.HasPrefix(fragment, ":~:") && !strings.Contains(fragment, "/")
+}
+
+// backoff returns the exponential delay before the given retry, with equal
+// jitter so concurrent checks against one host spread out.
+func (p *compiledURLPolicy) backoff(retry int) time.Duration {
+	delay := p.RetryBackoff << (retry - 1)
+	if delay <= 0 || delay > p.MaxRetryBackoff {
+		delay = p.MaxRetryBackoff
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int64N(int64(half)+1))
+}
+
+func newURLFinding(severity Severity, rawURL, format string, args ...any) *Finding {
+	err := fmt.Errorf(format, args...)
+	return &Finding{
+		Rule:     RuleURL,
+		Severity: severity,
+		Item:     rawURL,
+		Message:  err.Error(),
+		Err:      errors.Unwrap(err),
+	}
 }
 
 type URLValidator struct {
 	content *MarkdownContent
+	policy  *compiledURLPolicy
+	cache   *URLCache
+	client  *http.Client
 }
 
 func NewURLValidator(content *MarkdownContent) *URLValidator {
-	return &URLValidator{content: content}
+	uv, _ := NewURLValidatorWithPolicy(content, DefaultURLPolicy(), nil, nil)
+	return uv
+}
+
+// NewURLValidatorWithPolicy checks links with client and records results in
+// cache. A nil client is replaced by one without a timeout of its own, since
+// every request is bounded by URLPolicy.Timeout; a nil cache by the cache of
+// URLPolicy.CacheFile, or a fresh in-memory cache.
+func NewURLValidatorWithPolicy(content *MarkdownContent, policy URLPolicy, client *http.Client, cache *URLCache) (*URLValidator, error) {
+	compiled, err := compileURLPolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	if cache == nil {
+		if policy.CacheFile != "" {
+			cache, err = sharedURLCache(policy.CacheFile, compiled.CacheTTL)
+		} else {
+			cache, err = NewURLCache("", 0)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &URLValidator{content: content, policy: compiled, cache: cache, client: client}, nil
 }
 
 func (uv *URLValidator) Validate() []error {
+	return uv.ValidateContext(context.Background())
+}
+
+func (uv *URLValidator) ValidateContext(ctx context.Context) []error {
 	rxStrict := xurls.Strict()
-	urls := rxStrict.FindAllString(uv.content.data, -1)
+	matches := rxStrict.FindAllStringIndex(uv.content.data, -1)
+	urls := make([]string, len(matches))
+	for i, match := range matches {
+		urls[i] = uv.content.data[match[0]:match[1]]
+	}
 
-	const maxConcurrency = 5
-	sem := make(chan struct{}, maxConcurrency)
+	sem := make(chan struct{}, uv.policy.MaxConcurrency)
 	var wg sync.WaitGroup
-	errChan := make(chan error, len(urls))
+	results := make([]error, len(urls))
+	checked := make([]bool, len(urls))
 
-	for _, u := range urls {
-		if strings.Contains(u, "registry.terraform.io/providers/") {
+	for i, u := range urls {
+		check, err := uv.policy.decide(u)
+		if !check {
+			results[i] = err
+			checked[i] = true
 			continue
 		}
 		wg.Add(1)
-		go func(url string) {
+		go func(i int, url string) {
 			defer wg.Done()
-			sem <- struct{}{}
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
 			defer func() { <-sem }()
-			if err := validateSingleURL(url); err != nil {
-				errChan <- err
+
+			err := uv.validateSingleURL(ctx, url)
+			if err != nil && ctx.Err() != nil {
+				return
 			}
-		}(u)
+			results[i] = err
+			checked[i] = true
+		}(i, u)
 	}
 
 	wg.Wait()
-	close(errChan)
 
 	var errors []error
-	for err := range errChan {
+	unchecked := 0
+	for i, err := range results {
+		if !checked[i] {
+			unchecked++
+			continue
+		}
+		if err != nil {
+			line := strings.Count(uv.content.data[:matches[i][0]], "\n") + 1
+			errors = append(errors, locateURLFinding(err, Location{File: uv.content.path, Line: line}))
+		}
+	}
+
+	if unchecked > 0 {
+		errors = append(errors, fmt.Errorf("URL validation stopped with %d of %d URLs unchecked: %w", unchecked, len(urls), ctx.Err()))
+	}
+
+	if err := uv.cache.Save(); err != nil {
 		errors = append(errors, err)
 	}
 
 	return errors
 }
 
-func validateSingleURL(url string) error {
-	resp, err := httpClient.Get(url)
+// locateURLFinding points a finding at the README line of its link, so
+// reports and suppression comments can refer to it.
+func locateURLFinding(err error, location Location) error {
+	var finding *Finding
+	if errors.As(err, &finding) && finding.Location.File == "" {
+		finding.Location = location
+	}
+	return err
+}
+
+func (uv *URLValidator) validateSingleURL(ctx context.Context, rawURL string) error {
+	page, fragment, _ := strings.Cut(rawURL, "#")
+
+	entry, attempts, err := uv.checkStatus(ctx, rawURL, page)
 	if err != nil {
-		return fmt.Errorf("error accessing URL: %s: %w", url, err)
+		return err
+	}
+
+	finding := uv.policy.judge(rawURL, entry, attempts)
+	if !uv.policy.accepts(entry.Status) || !uv.policy.checksFragment(fragment) {
+		return finding
+	}
+	if err := uv.checkFragment(ctx, rawURL, page, fragment); err != nil {
+		return err
+	}
+	return finding
+}
+
+func (uv *URLValidator) checkStatus(ctx context.Context, rawURL, page string) (URLCacheEntry, int, error) {
+	if uv.policy.Offline {
+		if entry, ok := uv.cache.Get(page); ok {
+			return entry, 0, nil
+		}
+		return URLCacheEntry{}, 0, newURLFinding(SeverityInfo, rawURL, "URL unchecked: %s: offline mode and no cached result", rawURL)
+	}
+
+	result := uv.cache.check(ctx, page, func() urlCheckResult {
+		return uv.fetchWithRetry(ctx, page)
+	})
+	if result.err != nil {
+		return URLCacheEntry{}, 0, newURLFinding(SeverityError, rawURL, "error accessing URL: %s (%s): %w", rawURL, attemptsText(result.attempts), result.err)
+	}
+	return result.entry, result.attempts, nil
+}
+
+// checkFragment looks the fragment up among the id and name attributes of the
+// target page. Anchors are cached under the page URL with an empty fragment,
+// next to its status entry.
+func (uv *URLValidator) checkFragment(ctx context.Context, rawURL, page, fragment string) error {
+	key := page + "#"
+
+	var entry URLCacheEntry
+	if uv.policy.Offline {
+		cached, ok := uv.cache.Get(key)
+		if !ok {
+			return newURLFinding(SeverityInfo, rawURL, "URL fragment unchecked: %s: offline mode and no cached page", rawURL)
+		}
+		entry = cached
+	} else {
+		result := uv.cache.check(ctx, key, func() urlCheckResult {
+			return uv.fetchAnchors(ctx, page)
+		})
+		if result.err != nil {
+			return newURLFinding(SeverityError, rawURL, "error fetching page to check fragment: %s: %w", rawURL, result.err)
+		}
+		entry = result.entry
+	}
+
+	if !entry.HTML {
+		return nil
+	}
+	if slices.Contains(entry.Anchors, fragment) {
+		return nil
+	}
+	if unescaped, err := url.PathUnescape(fragment); err == nil && slices.Contains(entry.Anchors, unescaped) {
+		return nil
+	}
+	if entry.Truncated {
+		return newURLFinding(SeverityInfo, rawURL, "URL fragment unchecked: %s: page is larger than %d bytes", rawURL, uv.policy.MaxBodyBytes)
+	}
+
+	return newURLFinding(SeverityError, rawURL, "URL fragment not found on page: %s", rawURL)
+}
+
+func (uv *URLValidator) fetchAnchors(ctx context.Context, page string) urlCheckResult {
+	ctx, cancel := context.WithTimeout(ctx, uv.policy.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, page, nil)
+	if err != nil {
+		return urlCheckResult{err: err, attempts: 1}
+	}
+
+	resp, err := uv.client.Do(req)
+	if err != nil {
+		return urlCheckResult{err: err, attempts: 1}
 	}
 	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("URL returned non-OK status: %s: Status: %d", url, resp.StatusCode)
+
+	entry := URLCacheEntry{
+		Status:    resp.StatusCode,
+		CheckedAt: time.Now().UTC(),
+		FinalURL:  resp.Request.URL.String(),
 	}
-	return nil
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if !uv.policy.accepts(resp.StatusCode) || (mediaType != "text/html" && mediaType != "application/xhtml+xml") {
+		return urlCheckResult{entry: entry, attempts: 1}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, uv.policy.MaxBodyBytes+1))
+	if err != nil {
+		return urlCheckResult{entry: entry, attempts: 1, err: err}
+	}
+	if int64(len(body)) > uv.policy.MaxBodyBytes {
+		body = body[:uv.policy.MaxBodyBytes]
+		entry.Truncated = true
+	}
+
+	entry.HTML = true
+	entry.Anchors = extractAnchors(body)
+	return urlCheckResult{entry: entry, attempts: 1}
+}
+
+type fetchResult struct {
+	status    int
+	header    http.Header
+	finalURL  string
+	redirects []URLRedirect
+}
+
+// fetchWithRetry tries a HEAD request first and switches to GET for the rest
+// of the check when the server does not accept it. Transient failures are
+// retried with backoff, honoring Retry-After on 429 and 503 responses.
+func (uv *URLValidator) fetchWithRetry(ctx context.Context, url string) urlCheckResult {
+	method := http.MethodHead
+	attempts := 0
+
+	for {
+		attempts++
+
+		resp, err := uv.fetchOnce(ctx, method, url)
+		if err == nil && method == http.MethodHead && !uv.policy.accepts(resp.status) && !isRetryableStatus(resp.status) {
+			method = http.MethodGet
+			resp, err = uv.fetchOnce(ctx, method, url)
+		}
+
+		result := urlCheckResult{
+			entry: URLCacheEntry{
+				Status:    resp.status,
+				CheckedAt: time.Now().UTC(),
+				FinalURL:  resp.finalURL,
+				Redirects: resp.redirects,
+			},
+			attempts: attempts,
+			err:      err,
+		}
+
+		if err == nil && !isRetryableStatus(resp.status) {
+			return result
+		}
+		if ctx.Err() != nil || attempts > uv.policy.Retries {
+			return result
+		}
+
+		delay := uv.policy.backoff(attempts)
+		if retryAfter, ok := parseRetryAfter(resp.header, time.Now()); ok && (resp.status == http.StatusTooManyRequests || resp.status == http.StatusServiceUnavailable) {
+			delay = retryAfter
+			if delay > uv.policy.MaxRetryBackoff {
+				delay = uv.policy.MaxRetryBackoff
+			}
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			result.err = ctx.Err()
+			return result
+		}
+	}
+}
+
+func (uv *URLValidator) fetchOnce(ctx context.Context, method, url string) (fetchResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, uv.policy.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return fetchResult{}, err
+	}
+
+	resp, err := uv.client.Do(req)
+	if err != nil {
+		return fetchResult{}, err
+	}
+	defer resp.Body.Close()
+
+	return fetchResult{
+		status:    resp.StatusCode,
+		header:    resp.Header,
+		finalURL:  resp.Request.URL.String(),
+		redirects: redirectChain(resp.Request),
+	}, nil
+}
+
+// redirectChain rebuilds the hops the client followed to reach req, using the
+// redirect response recorded on each request.
+func redirectChain(req *http.Request) []URLRedirect {
+	var chain []URLRedirect
+	for req != nil && req.Response != nil {
+		chain = append(chain, URLRedirect{
+			From:   req.Response.Request.URL.String(),
+			To:     req.URL.String(),
+			Status: req.Response.StatusCode,
+		})
+		req = req.Response.Request
+	}
+	slices.Reverse(chain)
+	return chain
+}
+
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusInternalServerError,
+		http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func parseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := at.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+func attemptsText(attempts int) string {
+	if attempts == 0 {
+		return "cached result"
+	}
+	if attempts == 1 {
+		return "1 attempt"
+	}
+	return fmt.Sprintf("%d attempts", attempts)
 }
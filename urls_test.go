@@ -1,10 +1,16 @@
 package markparsr
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 type stubTransport struct {
@@ -36,12 +42,20 @@ func (s stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	}, nil
 }
 
-func withStubHTTPClient(t *testing.T, responses map[string]int, errs map[string]error) {
-	original := httpClient
-	httpClient = &http.Client{Transport: stubTransport{responses: responses, errURLs: errs}}
-	t.Cleanup(func() {
-		httpClient = original
-	})
+type blockingTransport struct{}
+
+func (blockingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func newTestURLValidator(t *testing.T, content *MarkdownContent, policy URLPolicy, transport http.RoundTripper) *URLValidator {
+	t.Helper()
+	uv, err := NewURLValidatorWithPolicy(content, policy, &http.Client{Transport: transport}, nil)
+	if err != nil {
+		t.Fatalf("NewURLValidatorWithPolicy() error = %v", err)
+	}
+	return uv
 }
 
 func TestNewURLValidator(t *testing.T) {
@@ -128,18 +142,18 @@ Bad: http://example.com/notfound
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			withStubHTTPClient(t, map[string]int{
+			transport := stubTransport{responses: map[string]int{
 				"http://example.com/ok":          http.StatusOK,
 				"http://example.com/docs":        http.StatusOK,
 				"http://example.com/notfound":    http.StatusNotFound,
 				"http://example.com/redirect":    http.StatusMovedPermanently,
 				"http://example.com/unreachable": http.StatusOK,
-			}, map[string]error{
+			}, errURLs: map[string]error{
 				"http://example.com/unreachable": fmt.Errorf("dial error"),
-			})
+			}}
 
 			mc := NewMarkdownContent(tt.markdown, FormatDocument, nil)
-			uv := NewURLValidator(mc)
+			uv := newTestURLValidator(t, mc, DefaultURLPolicy(), transport)
 
 			errs := uv.Validate()
 
@@ -200,15 +214,16 @@ func TestValidateSingleURL(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			withStubHTTPClient(t, map[string]int{
+			transport := stubTransport{responses: map[string]int{
 				"http://example.com/ok":       http.StatusOK,
 				"http://example.com/notfound": http.StatusNotFound,
 				"http://example.com/redirect": http.StatusMovedPermanently,
-			}, map[string]error{
+			}, errURLs: map[string]error{
 				"http://example.com/unreachable": fmt.Errorf("dial error"),
-			})
+			}}
 
-			err := validateSingleURL(tt.url)
+			uv := newTestURLValidator(t, NewMarkdownContent("", FormatDocument, nil), DefaultURLPolicy(), transport)
+			err := uv.validateSingleURL(t.Context(), tt.url)
 
 			if (err != nil) != tt.expectError {
 				t.Errorf("validateSingleURL() error = %v, expectError %v", err, tt.expectError)
@@ -237,7 +252,7 @@ func TestURLValidator_ConcurrentValidation(t *testing.T) {
 		"http://example.com/ok10",
 	}
 
-	withStubHTTPClient(t, map[string]int{
+	transport := stubTransport{responses: map[string]int{
 		"http://example.com/ok1":  http.StatusOK,
 		"http://example.com/ok2":  http.StatusOK,
 		"http://example.com/ok3":  http.StatusOK,
@@ -248,11 +263,11 @@ func TestURLValidator_ConcurrentValidation(t *testing.T) {
 		"http://example.com/ok8":  http.StatusOK,
 		"http://example.com/ok9":  http.StatusOK,
 		"http://example.com/ok10": http.StatusOK,
-	}, nil)
+	}}
 
 	markdown := "# Test\n\n" + strings.Join(urls, "\n")
 	mc := NewMarkdownContent(markdown, FormatDocument, nil)
-	uv := NewURLValidator(mc)
+	uv := newTestURLValidator(t, mc, DefaultURLPolicy(), transport)
 
 	errs := uv.Validate()
 
@@ -291,10 +306,10 @@ func TestURLValidator_TerraformRegistrySkip(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			withStubHTTPClient(t, nil, nil)
+			transport := stubTransport{}
 
 			mc := NewMarkdownContent(tt.markdown, FormatDocument, nil)
-			uv := NewURLValidator(mc)
+			uv := newTestURLValidator(t, mc, DefaultURLPolicy(), transport)
 
 			errs := uv.Validate()
 
@@ -351,13 +366,13 @@ No URLs in this content
 
 func TestURLValidator_Timeout(t *testing.T) {
 	timeoutURL := "http://example.com/timeout"
-	withStubHTTPClient(t, nil, map[string]error{
+	transport := stubTransport{errURLs: map[string]error{
 		timeoutURL: netErrorTimeout{},
-	})
+	}}
 
 	markdown := "URL: " + timeoutURL
 	mc := NewMarkdownContent(markdown, FormatDocument, nil)
-	uv := NewURLValidator(mc)
+	uv := newTestURLValidator(t, mc, DefaultURLPolicy(), transport)
 
 	errs := uv.Validate()
 
@@ -367,10 +382,10 @@ func TestURLValidator_Timeout(t *testing.T) {
 }
 
 func TestURLValidator_EmptyContent(t *testing.T) {
-	withStubHTTPClient(t, nil, nil)
+	transport := stubTransport{}
 
 	mc := NewMarkdownContent("", FormatDocument, nil)
-	uv := NewURLValidator(mc)
+	uv := newTestURLValidator(t, mc, DefaultURLPolicy(), transport)
 
 	errs := uv.Validate()
 
@@ -407,11 +422,11 @@ func TestURLValidator_MaxConcurrency(t *testing.T) {
 	for _, u := range urls {
 		responses[u] = http.StatusOK
 	}
-	withStubHTTPClient(t, responses, nil)
+	transport := stubTransport{responses: responses}
 
 	markdown := "# Test\n\n" + strings.Join(urls, "\n\n")
 	mc := NewMarkdownContent(markdown, FormatDocument, nil)
-	uv := NewURLValidator(mc)
+	uv := newTestURLValidator(t, mc, DefaultURLPolicy(), transport)
 
 	errs := uv.Validate()
 
@@ -419,3 +434,465 @@ func TestURLValidator_MaxConcurrency(t *testing.T) {
 		t.Errorf("Validate() with many URLs returned %d errors; want 0", len(errs))
 	}
 }
+
+func TestURLValidator_ValidateContext(t *testing.T) {
+	markdown := "# Test\n\nhttp://example.com/slow1\n\nhttp://example.com/slow2\n"
+	uv := newTestURLValidator(t, NewMarkdownContent(markdown, FormatDocument, nil), DefaultURLPolicy(), blockingTransport{})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	errs := uv.ValidateContext(ctx)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("ValidateContext() took %v after cancellation", elapsed)
+	}
+
+	if len(errs) != 1 {
+		t.Fatalf("ValidateContext() returned %d errors; want 1: %v", len(errs), errs)
+	}
+	if !strings.Contains(errs[0].Error(), "2 of 2 URLs unchecked") {
+		t.Errorf("ValidateContext() error = %v; want unchecked summary", errs[0])
+	}
+	if !errors.Is(errs[0], context.DeadlineExceeded) {
+		t.Errorf("ValidateContext() error = %v; want it to wrap context.DeadlineExceeded", errs[0])
+	}
+}
+
+func TestURLValidator_Policy(t *testing.T) {
+	markdown := `# Module
+
+- http://example.com/ok
+- http://example.com/forbidden
+- http://example.com/ratelimited
+- http://example.com/notfound
+- http://wiki.corp.internal/page
+- http://tracker.example.org/issue/1
+- http://blocked.example.net/page
+`
+
+	tests := []struct {
+		name        string
+		policy      URLPolicy
+		wantErrors  int
+		wantWarning int
+		contains    []string
+	}{
+		{
+			name:       "default policy",
+			policy:     DefaultURLPolicy(),
+			wantErrors: 3,
+		},
+		{
+			name: "skip patterns",
+			policy: URLPolicy{
+				SkipPatterns: []string{"http://example.com/*", "^http://tracker\\."},
+			},
+			wantErrors: 0,
+		},
+		{
+			name: "warning status codes",
+			policy: URLPolicy{
+				WarningStatusCodes: []int{http.StatusForbidden, http.StatusTooManyRequests},
+			},
+			wantErrors:  1,
+			wantWarning: 2,
+		},
+		{
+			name: "accepted status codes",
+			policy: URLPolicy{
+				AcceptedStatusCodes: []int{http.StatusOK, http.StatusForbidden, http.StatusTooManyRequests, http.StatusNotFound},
+			},
+			wantErrors: 0,
+		},
+		{
+			name: "allowed hosts limit what is checked",
+			policy: URLPolicy{
+				AllowedHosts: []string{"*.example.org"},
+			},
+			wantErrors: 0,
+		},
+		{
+			name: "denied hosts are reported without a request",
+			policy: URLPolicy{
+				SkipPatterns: []string{"http://example.com/*"},
+				DeniedHosts:  []string{"blocked.example.net"},
+			},
+			wantErrors: 1,
+			contains:   []string{"README.md:9: URL host is denied by policy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := stubTransport{responses: map[string]int{
+				"http://example.com/forbidden":   http.StatusForbidden,
+				"http://example.com/ratelimited": http.StatusTooManyRequests,
+				"http://example.com/notfound":    http.StatusNotFound,
+			}}
+
+			mc := NewMarkdownContent(markdown, FormatDocument, nil)
+			mc.path = "/module/README.md"
+			uv, err := NewURLValidatorWithPolicy(mc, tt.policy, &http.Client{Transport: transport}, nil)
+			if err != nil {
+				t.Fatalf("NewURLValidatorWithPolicy() error = %v", err)
+			}
+
+			var errs, warnings []error
+			for _, err := range uv.Validate() {
+				if severityOf(err) == SeverityWarning {
+					warnings = append(warnings, err)
+				} else {
+					errs = append(errs, err)
+				}
+			}
+
+			if len(errs) != tt.wantErrors || len(warnings) != tt.wantWarning {
+				t.Errorf("Validate() returned %d errors and %d warnings; want %d and %d", len(errs), len(warnings), tt.wantErrors, tt.wantWarning)
+				for _, err := range append(errs, warnings...) {
+					t.Logf("  %v", err)
+				}
+			}
+
+			for _, substr := range tt.contains {
+				found := false
+				for _, err := range errs {
+					if strings.Contains(err.Error(), substr) {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("Expected error containing %q, but not found", substr)
+				}
+			}
+		})
+	}
+}
+
+func TestNewURLValidatorWithPolicy(t *testing.T) {
+	mc := NewMarkdownContent("", FormatDocument, nil)
+
+	uv, err := NewURLValidatorWithPolicy(mc, URLPolicy{}, nil, nil)
+	if err != nil {
+		t.Fatalf("NewURLValidatorWithPolicy() error = %v", err)
+	}
+	defaults := DefaultURLPolicy()
+	if uv.policy.MaxConcurrency != defaults.MaxConcurrency || uv.policy.Timeout != defaults.Timeout || uv.policy.CacheTTL != defaults.CacheTTL {
+		t.Errorf("zero policy values were not defaulted: %+v", uv.policy.URLPolicy)
+	}
+	if !slices.Equal(uv.policy.AcceptedStatusCodes, []int{http.StatusOK}) {
+		t.Errorf("AcceptedStatusCodes = %v; want [200]", uv.policy.AcceptedStatusCodes)
+	}
+	if uv.client.Timeout != 0 {
+		t.Errorf("client.Timeout = %v; want none, so URLPolicy.Timeout is not capped", uv.client.Timeout)
+	}
+
+	if _, err := NewURLValidatorWithPolicy(mc, URLPolicy{SkipPatterns: []string{"^(bad"}}, nil, nil); err == nil {
+		t.Error("NewURLValidatorWithPolicy() expected error for invalid skip pattern")
+	}
+}
+
+func TestURLValidator_PolicyTimeout(t *testing.T) {
+	uv := newTestURLValidator(t, NewMarkdownContent("http://example.com/slow", FormatDocument, nil), URLPolicy{
+		Timeout: 20 * time.Millisecond,
+	}, blockingTransport{})
+
+	errs := uv.ValidateContext(t.Context())
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "error accessing URL") {
+		t.Errorf("ValidateContext() = %v; want a single request timeout error", errs)
+	}
+}
+
+type stubResponse struct {
+	status int
+	header http.Header
+	body   string
+}
+
+type sequenceTransport struct {
+	mu        sync.Mutex
+	responses map[string][]stubResponse
+	requests  []string
+}
+
+func (s *sequenceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := req.Method + " " + req.URL.String()
+	s.requests = append(s.requests, key)
+
+	queue := s.responses[key]
+	if len(queue) == 0 {
+		queue = s.responses[req.URL.String()]
+	}
+	if len(queue) == 0 {
+		return nil, fmt.Errorf("unexpected request: %s", key)
+	}
+
+	next := queue[0]
+	if len(queue) > 1 {
+		if _, ok := s.responses[key]; ok {
+			s.responses[key] = queue[1:]
+		} else {
+			s.responses[req.URL.String()] = queue[1:]
+		}
+	}
+
+	header := next.header
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{StatusCode: next.status, Body: io.NopCloser(strings.NewReader(next.body)), Header: header, Request: req}, nil
+}
+
+func newSequenceTransport(responses map[string][]stubResponse) *sequenceTransport {
+	return &sequenceTransport{responses: responses}
+}
+
+func TestURLValidator_Retries(t *testing.T) {
+	policy := URLPolicy{
+		Retries:         3,
+		RetryBackoff:    time.Millisecond,
+		MaxRetryBackoff: 5 * time.Millisecond,
+	}
+
+	t.Run("transient failure recovers", func(t *testing.T) {
+		transport := newSequenceTransport(map[string][]stubResponse{
+			"http://example.com/flaky": {{status: http.StatusBadGateway}, {status: http.StatusBadGateway}, {status: http.StatusOK}},
+		})
+		uv := newTestURLValidator(t, NewMarkdownContent("", FormatDocument, nil), policy, transport)
+
+		if err := uv.validateSingleURL(t.Context(), "http://example.com/flaky"); err != nil {
+			t.Errorf("validateSingleURL() error = %v; want nil", err)
+		}
+		if len(transport.requests) != 3 {
+			t.Errorf("made %d requests; want 3: %v", len(transport.requests), transport.requests)
+		}
+	})
+
+	t.Run("persistent failure reports attempts", func(t *testing.T) {
+		transport := newSequenceTransport(map[string][]stubResponse{
+			"http://example.com/down": {{status: http.StatusServiceUnavailable}},
+		})
+		uv := newTestURLValidator(t, NewMarkdownContent("", FormatDocument, nil), policy, transport)
+
+		err := uv.validateSingleURL(t.Context(), "http://example.com/down")
+		if err == nil || !strings.Contains(err.Error(), "Status: 503 (4 attempts)") {
+			t.Errorf("validateSingleURL() error = %v; want 503 after 4 attempts", err)
+		}
+	})
+
+	t.Run("non-retryable status is not retried", func(t *testing.T) {
+		transport := newSequenceTransport(map[string][]stubResponse{
+			"http://example.com/missing": {{status: http.StatusNotFound}},
+		})
+		uv := newTestURLValidator(t, NewMarkdownContent("", FormatDocument, nil), policy, transport)
+
+		err := uv.validateSingleURL(t.Context(), "http://example.com/missing")
+		if err == nil || !strings.Contains(err.Error(), "(1 attempt)") {
+			t.Errorf("validateSingleURL() error = %v; want 404 after 1 attempt", err)
+		}
+		if len(transport.requests) != 2 {
+			t.Errorf("made %d requests; want HEAD and GET: %v", len(transport.requests), transport.requests)
+		}
+	})
+
+	t.Run("retry-after is honored", func(t *testing.T) {
+		transport := newSequenceTransport(map[string][]stubResponse{
+			"http://example.com/limited": {
+				{status: http.StatusTooManyRequests, header: http.Header{"Retry-After": []string{"1"}}},
+				{status: http.StatusOK},
+			},
+		})
+		uv := newTestURLValidator(t, NewMarkdownContent("", FormatDocument, nil), URLPolicy{
+			Retries:         1,
+			RetryBackoff:    time.Millisecond,
+			MaxRetryBackoff: 50 * time.Millisecond,
+		}, transport)
+
+		start := time.Now()
+		if err := uv.validateSingleURL(t.Context(), "http://example.com/limited"); err != nil {
+			t.Errorf("validateSingleURL() error = %v; want nil", err)
+		}
+		if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+			t.Errorf("retry happened after %v; want Retry-After capped at 50ms", elapsed)
+		}
+	})
+}
+
+func TestURLValidator_HeadFallback(t *testing.T) {
+	transport := newSequenceTransport(map[string][]stubResponse{
+		"HEAD http://example.com/nohead": {{status: http.StatusMethodNotAllowed}},
+		"GET http://example.com/nohead":  {{status: http.StatusOK}},
+		"HEAD http://example.com/head":   {{status: http.StatusOK}},
+	})
+	uv := newTestURLValidator(t, NewMarkdownContent("", FormatDocument, nil), DefaultURLPolicy(), transport)
+
+	if err := uv.validateSingleURL(t.Context(), "http://example.com/nohead"); err != nil {
+		t.Errorf("validateSingleURL() error = %v; want GET fallback to succeed", err)
+	}
+	if err := uv.validateSingleURL(t.Context(), "http://example.com/head"); err != nil {
+		t.Errorf("validateSingleURL() error = %v", err)
+	}
+
+	want := []string{"HEAD http://example.com/nohead", "GET http://example.com/nohead", "HEAD http://example.com/head"}
+	if !slices.Equal(transport.requests, want) {
+		t.Errorf("requests = %v; want %v", transport.requests, want)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "seconds", value: "120", want: 2 * time.Minute, wantOK: true},
+		{name: "http date", value: "Thu, 01 Jan 2026 12:00:30 GMT", want: 30 * time.Second, wantOK: true},
+		{name: "date in the past", value: "Thu, 01 Jan 2026 11:00:00 GMT", want: 0, wantOK: true},
+		{name: "missing", value: "", wantOK: false},
+		{name: "invalid", value: "soon", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := make(http.Header)
+			if tt.value != "" {
+				header.Set("Retry-After", tt.value)
+			}
+
+			got, ok := parseRetryAfter(header, now)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCompiledURLPolicy_Backoff(t *testing.T) {
+	policy, _ := compileURLPolicy(URLPolicy{RetryBackoff: 100 * time.Millisecond, MaxRetryBackoff: time.Second})
+
+	for retry, ceiling := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		delay := policy.backoff(retry)
+		if delay < ceiling/2 || delay > ceiling {
+			t.Errorf("backoff(%d) = %v; want between %v and %v", retry, delay, ceiling/2, ceiling)
+		}
+	}
+}
+
+func TestURLValidator_Redirects(t *testing.T) {
+	location := func(target string) http.Header {
+		return http.Header{"Location": []string{target}}
+	}
+	transport := newSequenceTransport(map[string][]stubResponse{
+		"http://example.com/moved":     {{status: http.StatusMovedPermanently, header: location("http://example.com/new")}},
+		"http://example.com/permanent": {{status: http.StatusPermanentRedirect, header: location("http://example.com/moved")}},
+		"http://example.com/temporary": {{status: http.StatusFound, header: location("http://example.com/new")}},
+		"http://example.com/mixed":     {{status: http.StatusMovedPermanently, header: location("http://example.com/login")}},
+		"http://example.com/login":     {{status: http.StatusTemporaryRedirect, header: location("http://example.com/new")}},
+		"http://example.com/broken":    {{status: http.StatusMovedPermanently, header: location("http://example.com/missing")}},
+		"http://example.com/missing":   {{status: http.StatusNotFound}},
+		"http://example.com/new":       {{status: http.StatusOK}},
+	})
+
+	tests := []struct {
+		url        string
+		severity   Severity
+		suggestion string
+	}{
+		{url: "http://example.com/moved", severity: SeverityWarning, suggestion: "http://example.com/new"},
+		{url: "http://example.com/moved#limits", severity: SeverityWarning, suggestion: "http://example.com/new#limits"},
+		{url: "http://example.com/permanent", severity: SeverityWarning, suggestion: "http://example.com/new"},
+		{url: "http://example.com/temporary"},
+		{url: "http://example.com/mixed", severity: SeverityWarning, suggestion: "http://example.com/login"},
+		{url: "http://example.com/broken", severity: SeverityError},
+	}
+
+	uv := newTestURLValidator(t, NewMarkdownContent("", FormatDocument, nil), DefaultURLPolicy(), transport)
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := uv.validateSingleURL(t.Context(), tt.url)
+			if tt.severity == "" {
+				if err != nil {
+					t.Errorf("validateSingleURL() error = %v; want nil", err)
+				}
+				return
+			}
+
+			var finding *Finding
+			if !errors.As(err, &finding) {
+				t.Fatalf("validateSingleURL() error = %v; want a finding", err)
+			}
+			if finding.Severity != tt.severity || finding.Suggestion != tt.suggestion {
+				t.Errorf("finding = %s %q (suggest %q); want %s suggesting %q", finding.Severity, finding.Message, finding.Suggestion, tt.severity, tt.suggestion)
+			}
+		})
+	}
+
+	entry, _ := uv.cache.Get("http://example.com/permanent")
+	want := []URLRedirect{
+		{From: "http://example.com/permanent", To: "http://example.com/moved", Status: http.StatusPermanentRedirect},
+		{From: "http://example.com/moved", To: "http://example.com/new", Status: http.StatusMovedPermanently},
+	}
+	if !slices.Equal(entry.Redirects, want) {
+		t.Errorf("cached redirects = %v; want %v", entry.Redirects, want)
+	}
+}
+
+func TestURLValidator_Fragments(t *testing.T) {
+	htmlHeader := http.Header{"Content-Type": []string{"text/html; charset=utf-8"}}
+	page := `<html><body><h2 id="limits">Limits</h2><a name='quotas'></a><h2 id="user-content-setup">Setup</h2></body></html>`
+
+	transport := newSequenceTransport(map[string][]stubResponse{
+		"HEAD http://example.com/docs": {{status: http.StatusOK}},
+		"GET http://example.com/docs":  {{status: http.StatusOK, header: htmlHeader, body: page}},
+		"HEAD http://example.com/big":  {{status: http.StatusOK}},
+		"GET http://example.com/big":   {{status: http.StatusOK, header: htmlHeader, body: strings.Repeat("x", 256) + `<h2 id="late">`}},
+		"HEAD http://example.com/file": {{status: http.StatusOK}},
+		"GET http://example.com/file":  {{status: http.StatusOK, header: http.Header{"Content-Type": []string{"text/plain"}}}},
+	})
+
+	uv := newTestURLValidator(t, NewMarkdownContent("", FormatDocument, nil), URLPolicy{
+		CheckFragments: true,
+		MaxBodyBytes:   256,
+	}, transport)
+
+	tests := []struct {
+		url      string
+		severity Severity
+	}{
+		{url: "http://example.com/docs#limits"},
+		{url: "http://example.com/docs#quotas"},
+		{url: "http://example.com/docs#setup"},
+		{url: "http://example.com/docs#/route"},
+		{url: "http://example.com/docs#removed", severity: SeverityError},
+		{url: "http://example.com/big#late", severity: SeverityInfo},
+		{url: "http://example.com/file#L10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := uv.validateSingleURL(t.Context(), tt.url)
+			if tt.severity == "" {
+				if err != nil {
+					t.Errorf("validateSingleURL() error = %v; want nil", err)
+				}
+				return
+			}
+			if err == nil || severityOf(err) != tt.severity {
+				t.Errorf("validateSingleURL() error = %v; want %s finding", err, tt.severity)
+			}
+		})
+	}
+
+	if got := len(transport.requests); got != 6 {
+		t.Errorf("made %d requests; want one HEAD and one GET per page: %v", got, transport.requests)
+	}
+	if _, ok := uv.cache.Get("http://example.com/docs#"); !ok {
+		t.Error("page anchors are not in the URL cache")
+	}
+}
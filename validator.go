@@ -1,10 +1,14 @@
 package markparsr
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 )
 
 type Options struct {
@@ -13,6 +17,15 @@ type Options struct {
 	AdditionalFiles    []string
 	ReadmePath         string
 	ProviderPrefixes   []string
+	TolerantParsing    bool
+	ValidatorTimeout   time.Duration
+	URLPolicy          URLPolicy
+	URLCache           *URLCache
+	HTTPClient         *http.Client
+	RegistryVersion    RegistryVersionPolicy
+	MinCoverage        float64
+	BaselineFile       string
+	IgnoredItems       map[string][]string
 }
 
 type Option func(*Options)
@@ -47,12 +60,93 @@ func WithProviderPrefixes(prefixes ...string) Option {
 	}
 }
 
+func WithTolerantParsing() Option {
+	return func(o *Options) {
+		o.TolerantParsing = true
+	}
+}
+
+func WithValidatorTimeout(timeout time.Duration) Option {
+	return func(o *Options) {
+		o.ValidatorTimeout = timeout
+	}
+}
+
+func WithURLPolicy(policy URLPolicy) Option {
+	return func(o *Options) {
+		o.URLPolicy = policy
+	}
+}
+
+// WithURLCache shares one cache between validators, so a run over several
+// modules checks every unique URL only once.
+func WithURLCache(cache *URLCache) Option {
+	return func(o *Options) {
+		o.URLCache = cache
+	}
+}
+
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *Options) {
+		o.HTTPClient = client
+	}
+}
+
+// WithHTTPTransport routes link checks through transport, for example a
+// FixtureTransport replaying recorded responses in a sandbox.
+func WithHTTPTransport(transport http.RoundTripper) Option {
+	return func(o *Options) {
+		o.HTTPClient = &http.Client{Transport: transport}
+	}
+}
+
+// WithRegistryVersionPolicy enables offline checks of registry.terraform.io
+// provider links and requires them to use "latest" or a pinned version;
+// RegistryVersionAny accepts either.
+func WithRegistryVersionPolicy(policy RegistryVersionPolicy) Option {
+	return func(o *Options) {
+		o.RegistryVersion = policy
+	}
+}
+
+// WithMinCoverage fails the run when any coverage metric, such as the share
+// of documented variables, is below percent (0-100).
+func WithMinCoverage(percent float64) Option {
+	return func(o *Options) {
+		o.MinCoverage = percent
+	}
+}
+
+// WithBaselineFile suppresses the findings recorded in a baseline written by
+// WriteBaseline and reports baseline entries that no longer match.
+func WithBaselineFile(path string) Option {
+	return func(o *Options) {
+		o.BaselineFile = path
+	}
+}
+
+// WithIgnoredItems excludes variable, output, resource or data items whose
+// name matches one of patterns from the README comparison and coverage, for
+// example WithIgnoredItems("variable", "^_") or WithIgnoredItems("resource",
+// "terraform_data"). Resource and data patterns match the full name or the
+// type.
+func WithIgnoredItems(blockType string, patterns ...string) Option {
+	return func(o *Options) {
+		if o.IgnoredItems == nil {
+			o.IgnoredItems = make(map[string][]string)
+		}
+		o.IgnoredItems[blockType] = append(o.IgnoredItems[blockType], patterns...)
+	}
+}
+
 type ReadmeValidator struct {
 	readmePath string
 	modulePath string
 	markdown   *MarkdownContent
 	terraform  *TerraformContent
 	validators []Validator
+	baseline   *Baseline
+	ignore     ItemIgnores
 	options    Options
 }
 
@@ -63,6 +157,7 @@ func NewReadmeValidator(opts ...Option) (*ReadmeValidator, error) {
 		AdditionalFiles:    []string{},
 		ReadmePath:         "",
 		ProviderPrefixes:   []string{},
+		URLPolicy:          DefaultURLPolicy(),
 	}
 
 	for _, opt := range opts {
@@ -115,44 +210,237 @@ func NewReadmeValidator(opts ...Option) (*ReadmeValidator, error) {
 	}
 
 	markdown := NewMarkdownContent(string(data), options.Format, options.ProviderPrefixes)
+	markdown.path = readmeFile
 
-	terraform, err := NewTerraformContent(absModulePath)
+	newContent := NewTerraformContent
+	if options.TolerantParsing {
+		newContent = NewTolerantTerraformContent
+	}
+	terraform, err := newContent(absModulePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize terraform content: %w", err)
 	}
 
+	ignore, err := CompileItemIgnores(options.IgnoredItems)
+	if err != nil {
+		return nil, err
+	}
+
 	validator := &ReadmeValidator{
 		readmePath: readmeFile,
 		modulePath: absModulePath,
 		markdown:   markdown,
 		terraform:  terraform,
 		options:    options,
+		ignore:     ignore,
+	}
+
+	validator.validators, err = buildDefaultValidators(readmeFile, absModulePath, markdown, terraform, options, ignore)
+	if err != nil {
+		return nil, err
 	}
 
-	validator.validators = buildDefaultValidators(readmeFile, absModulePath, markdown, terraform, options)
+	if options.BaselineFile != "" {
+		if validator.baseline, err = LoadBaseline(options.BaselineFile); err != nil {
+			return nil, err
+		}
+	}
 
 	return validator, nil
 }
 
-func buildDefaultValidators(readmePath, modulePath string, markdown *MarkdownContent, terraform *TerraformContent, options Options) []Validator {
-	return []Validator{
+func buildDefaultValidators(readmePath, modulePath string, markdown *MarkdownContent, terraform *TerraformContent, options Options, ignore ItemIgnores) ([]Validator, error) {
+	urlValidator, err := NewURLValidatorWithPolicy(markdown, options.URLPolicy, options.HTTPClient, options.URLCache)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure URL validation: %w", err)
+	}
+
+	switch options.RegistryVersion {
+	case "", RegistryVersionAny, RegistryVersionLatest, RegistryVersionPinned:
+	default:
+		return nil, fmt.Errorf("unknown registry version policy: %q", options.RegistryVersion)
+	}
+
+	if options.MinCoverage < 0 || options.MinCoverage > 100 {
+		return nil, fmt.Errorf("minimum coverage must be between 0 and 100: %g", options.MinCoverage)
+	}
+
+	definitionValidator := NewTerraformDefinitionValidator(markdown, terraform)
+	definitionValidator.ignore = ignore
+	variableValidator := NewItemValidator(markdown, terraform, "Variables", "variable", []string{"Required Inputs", "Optional Inputs"}, "variables.tf")
+	variableValidator.ignore = ignore
+	outputValidator := NewItemValidator(markdown, terraform, "Outputs", "output", []string{"Outputs"}, "outputs.tf")
+	outputValidator.ignore = ignore
+
+	validators := []Validator{
 		NewSectionValidator(markdown, options.AdditionalSections),
 		NewFileValidator(readmePath, modulePath, options.AdditionalFiles),
-		NewURLValidator(markdown),
-		NewTerraformDefinitionValidator(markdown, terraform),
-		NewItemValidator(markdown, terraform, "Variables", "variable", []string{"Required Inputs", "Optional Inputs"}, "variables.tf"),
-		NewItemValidator(markdown, terraform, "Outputs", "output", []string{"Outputs"}, "outputs.tf"),
+		urlValidator,
+		NewRelativeLinkValidator(markdown, readmePath),
+		definitionValidator,
+		variableValidator,
+		outputValidator,
+	}
+
+	if options.RegistryVersion != "" {
+		validators = append(validators, NewRegistryLinkValidator(markdown, terraform, options.RegistryVersion))
+	}
+
+	if options.TolerantParsing {
+		validators = append(validators, NewTerraformDiagnosticsValidator(terraform))
 	}
+
+	if options.MinCoverage > 0 {
+		validators = append(validators, NewCoverageValidator(markdown, terraform, options.MinCoverage, ignore))
+	}
+
+	return validators, nil
 }
 
 func (rv *ReadmeValidator) Validate() []error {
-	collector := &ErrorCollector{}
+	return rv.ValidateContext(context.Background())
+}
+
+func (rv *ReadmeValidator) ValidateContext(ctx context.Context) []error {
+	return rv.Run(ctx).Errors
+}
 
-	for _, validator := range rv.validators {
-		collector.AddMany(validator.Validate())
+// Run executes every validator and separates failures from findings that
+// only warrant a warning, such as URLs answering with a tolerated status, and
+// informational notices such as URLs left unchecked in offline mode. Findings
+// covered by an inline suppression comment are moved to Suppressed and those
+// recorded in the baseline to Baselined.
+func (rv *ReadmeValidator) Run(ctx context.Context) *Result {
+	runs := make([]ValidatorRun, len(rv.validators))
+	results := make([][]error, len(rv.validators))
+
+	var wg sync.WaitGroup
+	for i, validator := range rv.validators {
+		wg.Add(1)
+		go func(i int, validator Validator) {
+			defer wg.Done()
+			start := time.Now()
+			errs, skipped := rv.runValidator(ctx, validator)
+			results[i] = errs
+			runs[i] = ValidatorRun{
+				Name:     validatorName(validator),
+				Rule:     validatorRule(validator),
+				Duration: time.Since(start),
+				Skipped:  skipped,
+			}
+		}(i, validator)
+	}
+	wg.Wait()
+
+	suppressions := rv.loadSuppressions()
+	matched := make(map[string]bool)
+
+	var suppressed, baselined []*Finding
+	for i, errs := range results {
+		var kept []error
+		for _, err := range errs {
+			finding := asFinding(err, runs[i].Rule)
+			if suppressedBy(suppressions, finding, rv.readmePath) {
+				suppressed = append(suppressed, finding)
+				continue
+			}
+			if rv.baseline != nil {
+				if fingerprint, ok := rv.baseline.contains(rv.modulePath, finding); ok {
+					matched[fingerprint] = true
+					baselined = append(baselined, finding)
+					continue
+				}
+			}
+			kept = append(kept, err)
+		}
+		results[i] = kept
 	}
 
-	return collector.Errors()
+	if len(suppressions) > 0 {
+		runs = append(runs, ValidatorRun{Name: "Suppressions", Rule: RuleUnusedSuppression})
+		results = append(results, unusedSuppressions(suppressions))
+	}
+	if rv.baseline != nil {
+		runs = append(runs, ValidatorRun{Name: "Baseline", Rule: RuleBaselineStale})
+		results = append(results, rv.baseline.stale(rv.modulePath, matched))
+	}
+
+	errors := &ErrorCollector{}
+	warnings := &ErrorCollector{}
+	notices := &ErrorCollector{}
+	for i, errs := range results {
+		for _, err := range errs {
+			runs[i].Findings = append(runs[i].Findings, asFinding(err, runs[i].Rule))
+
+			switch severityOf(err) {
+			case SeverityError:
+				errors.Add(err)
+			case SeverityWarning:
+				warnings.Add(err)
+			default:
+				notices.Add(err)
+			}
+		}
+	}
+
+	format := FormatDocument
+	if rv.markdown != nil {
+		format = rv.markdown.format
+	}
+
+	var coverage *Coverage
+	if rv.markdown != nil && rv.terraform != nil {
+		coverage, _ = computeCoverage(rv.markdown, rv.terraform, rv.ignore)
+	}
+
+	return &Result{
+		ModulePath: rv.modulePath,
+		ReadmePath: rv.readmePath,
+		Format:     format,
+		Coverage:   coverage,
+		Validators: runs,
+		Errors:     errors.Errors(),
+		Warnings:   warnings.Errors(),
+		Notices:    notices.Errors(),
+		Suppressed: suppressed,
+		Baselined:  baselined,
+	}
+}
+
+// runValidator gives each validator its own deadline. Validators that do not
+// accept a context are abandoned once it expires so the run can still return
+// the results gathered so far.
+func (rv *ReadmeValidator) runValidator(ctx context.Context, validator Validator) ([]error, bool) {
+	if rv.options.ValidatorTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, rv.options.ValidatorTimeout)
+		defer cancel()
+	}
+
+	if err := ctx.Err(); err != nil {
+		return []error{fmt.Errorf("%s skipped: %w", validatorName(validator), err)}, true
+	}
+
+	if cv, ok := validator.(ContextValidator); ok {
+		return cv.ValidateContext(ctx), false
+	}
+
+	done := make(chan []error, 1)
+	go func() {
+		done <- validator.Validate()
+	}()
+
+	select {
+	case errs := <-done:
+		return errs, false
+	case <-ctx.Done():
+		return []error{fmt.Errorf("%s did not finish: %w", validatorName(validator), ctx.Err())}, false
+	}
+}
+
+func validatorName(validator Validator) string {
+	name := fmt.Sprintf("%T", validator)
+	return name[strings.LastIndex(name, ".")+1:]
 }
 
 func (rv *ReadmeValidator) GetFormat() MarkdownFormat {
@@ -1,10 +1,15 @@
 package markparsr
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewReadmeValidator(t *testing.T) {
@@ -259,6 +264,140 @@ variable "name" {
 	}
 }
 
+func TestReadmeValidator_ValidateDeterministicOrder(t *testing.T) {
+	tmpDir := t.TempDir()
+	readmePath := filepath.Join(tmpDir, "README.md")
+
+	os.WriteFile(readmePath, []byte(`# Test Module
+
+## Required Inputs
+
+### <a name="input_documented"></a> documented
+
+## Outputs
+
+### <a name="output_documented"></a> documented
+`), 0o644)
+	os.WriteFile(filepath.Join(tmpDir, "variables.tf"), []byte(`
+variable "alpha" {}
+variable "beta" {}
+variable "gamma" {}
+`), 0o644)
+	os.WriteFile(filepath.Join(tmpDir, "outputs.tf"), []byte(`
+output "one" {}
+output "two" {}
+`), 0o644)
+
+	var first []string
+	for i := range 10 {
+		rv, err := NewReadmeValidator(WithRelativeReadmePath(readmePath))
+		if err != nil {
+			t.Fatalf("NewReadmeValidator() failed: %v", err)
+		}
+
+		var messages []string
+		for _, err := range rv.Validate() {
+			messages = append(messages, err.Error())
+		}
+
+		if i == 0 {
+			first = messages
+			continue
+		}
+		if !slices.Equal(first, messages) {
+			t.Fatalf("Validate() run %d returned errors in a different order:\n%v\nwant:\n%v", i, messages, first)
+		}
+	}
+}
+
+type stubValidator struct {
+	delay time.Duration
+	errs  []error
+}
+
+func (sv stubValidator) Validate() []error {
+	time.Sleep(sv.delay)
+	return sv.errs
+}
+
+func TestReadmeValidator_ValidateContext(t *testing.T) {
+	t.Run("per-validator timeout keeps partial results", func(t *testing.T) {
+		rv := &ReadmeValidator{
+			options: Options{ValidatorTimeout: 20 * time.Millisecond},
+			validators: []Validator{
+				stubValidator{errs: []error{fmt.Errorf("fast finding")}},
+				stubValidator{delay: time.Second},
+			},
+		}
+
+		errs := rv.ValidateContext(t.Context())
+		if len(errs) != 2 {
+			t.Fatalf("ValidateContext() returned %d errors; want 2: %v", len(errs), errs)
+		}
+		if errs[0].Error() != "fast finding" {
+			t.Errorf("first error = %v; want fast finding", errs[0])
+		}
+		if !strings.Contains(errs[1].Error(), "stubValidator did not finish") || !errors.Is(errs[1], context.DeadlineExceeded) {
+			t.Errorf("second error = %v; want timeout for stubValidator", errs[1])
+		}
+	})
+
+	t.Run("cancelled context skips validators", func(t *testing.T) {
+		rv := &ReadmeValidator{
+			validators: []Validator{stubValidator{errs: []error{fmt.Errorf("finding")}}},
+		}
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		errs := rv.ValidateContext(ctx)
+		if len(errs) != 1 || !errors.Is(errs[0], context.Canceled) {
+			t.Errorf("ValidateContext() = %v; want a single cancellation error", errs)
+		}
+	})
+}
+
+func TestReadmeValidator_Run(t *testing.T) {
+	rv := &ReadmeValidator{
+		validators: []Validator{
+			stubValidator{errs: []error{
+				fmt.Errorf("plain error"),
+				&Finding{Severity: SeverityWarning, Message: "rate limited"},
+				&Finding{Severity: SeverityError, Message: "not found"},
+				&Finding{Severity: SeverityInfo, Message: "unchecked"},
+			}},
+		},
+	}
+
+	result := rv.Run(t.Context())
+	if len(result.Errors) != 2 {
+		t.Errorf("Run() returned %d errors; want 2: %v", len(result.Errors), result.Errors)
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0].Error() != "rate limited" {
+		t.Errorf("Run() warnings = %v; want [rate limited]", result.Warnings)
+	}
+	if len(result.Notices) != 1 || result.Notices[0].Error() != "unchecked" {
+		t.Errorf("Run() notices = %v; want [unchecked]", result.Notices)
+	}
+	if errs := rv.Validate(); len(errs) != 2 {
+		t.Errorf("Validate() returned %d errors; want only the 2 failures", len(errs))
+	}
+}
+
+func TestNewReadmeValidator_InvalidURLPolicy(t *testing.T) {
+	tmpDir := t.TempDir()
+	readmePath := filepath.Join(tmpDir, "README.md")
+	os.WriteFile(readmePath, []byte("# Test"), 0o644)
+
+	_, err := NewReadmeValidator(
+		WithRelativeReadmePath(readmePath),
+		WithURLPolicy(URLPolicy{SkipPatterns: []string{"^(unclosed"}}),
+	)
+	if err == nil {
+		t.Error("NewReadmeValidator() expected error for invalid URL policy")
+	}
+}
+
 func TestReadmeValidator_GetFormat(t *testing.T) {
 	tmpDir := t.TempDir()
 	readmePath := filepath.Join(tmpDir, "README.md")
@@ -384,25 +523,49 @@ func TestBuildDefaultValidators(t *testing.T) {
 		name               string
 		additionalSections []string
 		additionalFiles    []string
+		tolerantParsing    bool
+		minCoverage        float64
+		registryVersion    RegistryVersionPolicy
 		expectedCount      int
 	}{
 		{
 			name:               "default validators",
 			additionalSections: []string{},
 			additionalFiles:    []string{},
-			expectedCount:      6, // Section, File, URL, TerraformDef, Items(Variables), Items(Outputs)
+			expectedCount:      7, // Section, File, URL, RelativeLink, TerraformDef, Items(Variables), Items(Outputs)
 		},
 		{
 			name:               "with additional sections",
 			additionalSections: []string{"Examples"},
 			additionalFiles:    []string{},
-			expectedCount:      6,
+			expectedCount:      7,
 		},
 		{
 			name:               "with additional files",
 			additionalSections: []string{},
 			additionalFiles:    []string{"main.tf"},
-			expectedCount:      6,
+			expectedCount:      7,
+		},
+		{
+			name:               "with tolerant parsing",
+			additionalSections: []string{},
+			additionalFiles:    []string{},
+			tolerantParsing:    true,
+			expectedCount:      8,
+		},
+		{
+			name:               "with minimum coverage",
+			additionalSections: []string{},
+			additionalFiles:    []string{},
+			minCoverage:        80,
+			expectedCount:      8,
+		},
+		{
+			name:               "with registry version policy",
+			additionalSections: []string{},
+			additionalFiles:    []string{},
+			registryVersion:    RegistryVersionAny,
+			expectedCount:      8,
 		},
 	}
 
@@ -411,9 +574,15 @@ func TestBuildDefaultValidators(t *testing.T) {
 			opts := Options{
 				AdditionalSections: tt.additionalSections,
 				AdditionalFiles:    tt.additionalFiles,
+				TolerantParsing:    tt.tolerantParsing,
+				MinCoverage:        tt.minCoverage,
+				RegistryVersion:    tt.registryVersion,
 			}
 
-			validators := buildDefaultValidators(readmePath, tmpDir, mc, tc, opts)
+			validators, err := buildDefaultValidators(readmePath, tmpDir, mc, tc, opts, nil)
+			if err != nil {
+				t.Fatalf("buildDefaultValidators() error = %v", err)
+			}
 
 			if len(validators) != tt.expectedCount {
 				t.Errorf("buildDefaultValidators() returned %d validators; want %d", len(validators), tt.expectedCount)
@@ -531,6 +700,112 @@ resource "azurerm_virtual_network" "main" {
 			t.Logf("  error %d: %v", i+1, err)
 		}
 	}
+
+	result := rv.Run(t.Context())
+	if result.Coverage == nil {
+		t.Fatal("Run() did not compute coverage")
+	}
+	if got := result.Coverage.Documentation(); got != (CoverageMetric{Documented: 7, Total: 7}) {
+		t.Errorf("Run() documentation coverage = %+v; want 7 of 7", got)
+	}
+	if got := result.Coverage.VariableDescriptions; got != (CoverageMetric{Documented: 0, Total: 3}) {
+		t.Errorf("Run() variable description coverage = %+v; want 0 of 3", got)
+	}
+}
+
+func TestReadmeValidator_IgnoredItems(t *testing.T) {
+	tmpDir := t.TempDir()
+	readmePath := filepath.Join(tmpDir, "README.md")
+
+	os.WriteFile(readmePath, []byte(`# Module
+
+## Resources
+
+- [azurerm_resource_group.main](https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/resources/resource_group) (resource)
+
+## Required Inputs
+
+### <a name="input_name"></a> name
+
+The name.
+
+## Outputs
+
+### <a name="output_id"></a> id
+
+The id.
+`), 0o644)
+	os.WriteFile(filepath.Join(tmpDir, "variables.tf"), []byte(`
+variable "name" {
+  description = "The name."
+  type        = string
+}
+
+variable "_trace" {
+  type = bool
+}
+`), 0o644)
+	os.WriteFile(filepath.Join(tmpDir, "outputs.tf"), []byte(`
+output "id" {
+  description = "The id."
+  value       = azurerm_resource_group.main.id
+}
+
+output "debug_state" {
+  value = terraform_data.replacement
+}
+`), 0o644)
+	os.WriteFile(filepath.Join(tmpDir, "main.tf"), []byte(`
+resource "azurerm_resource_group" "main" {
+  name = var.name
+}
+
+resource "terraform_data" "replacement" {
+  input = var.name
+}
+`), 0o644)
+
+	ignores := []Option{
+		WithRelativeReadmePath(readmePath),
+		WithProviderPrefixes("azurerm_", "terraform_"),
+		WithIgnoredItems("variable", "^_"),
+		WithIgnoredItems("output", "debug_*"),
+		WithIgnoredItems("resource", "terraform_data"),
+		WithMinCoverage(100),
+	}
+
+	rv, err := NewReadmeValidator(ignores[:2]...)
+	if err != nil {
+		t.Fatalf("NewReadmeValidator() error = %v", err)
+	}
+	var itemErrors int
+	for _, finding := range rv.Run(t.Context()).Findings() {
+		if finding.Rule == RuleVariables || finding.Rule == RuleOutputs || finding.Rule == RuleResources {
+			itemErrors++
+		}
+	}
+	if itemErrors != 3 {
+		t.Errorf("Run() without ignores reported %d item findings; want 3", itemErrors)
+	}
+
+	rv, err = NewReadmeValidator(ignores...)
+	if err != nil {
+		t.Fatalf("NewReadmeValidator() error = %v", err)
+	}
+	result := rv.Run(t.Context())
+	for _, finding := range result.Findings() {
+		switch finding.Rule {
+		case RuleVariables, RuleOutputs, RuleResources, RuleCoverage:
+			t.Errorf("Run() with ignores reported %v", finding)
+		}
+	}
+	if got := result.Coverage.Documentation(); got != (CoverageMetric{Documented: 3, Total: 3}) {
+		t.Errorf("Run() documentation coverage = %+v; want ignored items excluded", got)
+	}
+
+	if _, err := NewReadmeValidator(WithRelativeReadmePath(readmePath), WithIgnoredItems("module", "*")); err == nil {
+		t.Error("NewReadmeValidator() expected error for an unknown block type")
+	}
 }
 
 func TestReadmeValidator_Options(t *testing.T) {